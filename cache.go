@@ -0,0 +1,33 @@
+package reflector
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Compiled schema of struct type, fields have binding plan with indexes of struct fields,
+// indexes of is_required_if siblings, setters for kinds and fields of elements
+type schema struct {
+	fields []reflectionField
+	errors SchemaErrors
+}
+
+// Key of compiled schema
+type schemaKey struct {
+	structType reflect.Type
+	tagName    string
+}
+
+// Process wide cache of compiled schemas, fields of compiled schema must not be changed
+var schemaCache sync.Map
+
+// Get compiled schema for struct type from cache or processing tags
+func compileSchema(structType reflect.Type, tagName string) *schema {
+	key := schemaKey{structType: structType, tagName: tagName}
+	if cached, ok := schemaCache.Load(key); ok {
+		return cached.(*schema)
+	}
+	fields, errs := processingTags(structType, tagName)
+	compiled, _ := schemaCache.LoadOrStore(key, &schema{fields: fields, errors: errs})
+	return compiled.(*schema)
+}
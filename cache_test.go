@@ -0,0 +1,145 @@
+package reflector
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+type benchmarkConfig struct {
+	Host    string  `config:"host is_required"`
+	Port    int     `config:"port has_default 8080"`
+	Percent float64 `config:"percent"`
+	SslMode bool    `config:"ssl_mode"`
+	SslCert string  `config:"ssl_cert is_required_if ssl_mode has_value true"`
+	Server  struct {
+		Name   string `config:"name is_required"`
+		Params []struct {
+			Label string `config:"label"`
+		} `config:"params"`
+	} `config:"server"`
+	Labels map[string]string `config:"labels"`
+}
+
+var benchmarkData = []byte(`{"host":"localhost","percent":0.5,"server":{"name":"main","params":[{"label":"a"}]},
+	"labels":{"env":"prod"}}`)
+
+func TestSchemaCache(t *testing.T) {
+	var wg sync.WaitGroup
+	reflectors := make([]*Reflector, 10)
+	for i := range reflectors {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, err := New(&benchmarkConfig{}, "config")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			reflectors[i] = r
+		}(i)
+	}
+	wg.Wait()
+	for _, r := range reflectors[1:] {
+		if r == nil || reflectors[0] == nil {
+			t.Fatal("reflector is not created")
+		}
+		if &r.fields[0] != &reflectors[0].fields[0] {
+			t.Error("compiled schema must be shared")
+		}
+	}
+
+	// different tag names have own schemas
+	if compiled := compileSchema(reflect.TypeOf(benchmarkConfig{}), "json"); len(compiled.fields) != 0 {
+		t.Error("there are no fields with json tag")
+	}
+}
+
+func BenchmarkProcessingTags(b *testing.B) {
+	typeOf := reflect.TypeOf(benchmarkConfig{})
+	for i := 0; i < b.N; i++ {
+		processingTags(typeOf, "config")
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := New(&benchmarkConfig{}, "config"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := New(&benchmarkConfig{}, "config"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// Provider of decoded data, so benchmarks measure binding without decoding
+type staticDataProvider map[string]interface{}
+
+func (provider staticDataProvider) Load() (map[string]interface{}, error) { return provider, nil }
+func (provider staticDataProvider) Unload(map[string]interface{}) error   { return nil }
+func (provider staticDataProvider) Data() interface{}                     { return provider }
+
+func benchmarkProvider(b *testing.B) staticDataProvider {
+	data, err := providers.NewJsonDataProvider(benchmarkData).Load()
+	if err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
+
+// Binding with reflector created once
+func BenchmarkSetValues(b *testing.B) {
+	provider := benchmarkProvider(b)
+	r, err := New(&benchmarkConfig{}, "config")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := r.SetValues(provider); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Reflector per loading with cached schema e.g. per tenant
+func BenchmarkNewSetValues(b *testing.B) {
+	provider := benchmarkProvider(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, err := New(&benchmarkConfig{}, "config")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := r.SetValues(provider); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Reflector per loading without cache, tags are processed for every reflector
+func BenchmarkNewSetValuesUncached(b *testing.B) {
+	provider := benchmarkProvider(b)
+	typeOf := reflect.TypeOf(benchmarkConfig{})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fields, errs := processingTags(typeOf, "config")
+		if len(errs) > 0 {
+			b.Fatal(errs)
+		}
+		r := &Reflector{source: &benchmarkConfig{}, tagName: "config", fields: fields}
+		if _, err := r.SetValues(provider); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
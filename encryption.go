@@ -0,0 +1,119 @@
+package reflector
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Prefix of encrypted values, full format is enc:v1:<key id>:<base64 of nonce and AES-GCM ciphertext>
+const encryptedPrefix = "enc:v1:"
+
+// Keys for encrypted values by id, the last added key is used for encryption
+type KeyRing struct {
+	mutex   sync.RWMutex
+	keys    map[string]cipher.AEAD
+	primary string
+}
+
+// Create empty key ring
+func NewKeyRing() *KeyRing {
+	return &KeyRing{keys: map[string]cipher.AEAD{}}
+}
+
+// Add AES key of 16, 24 or 32 bytes, older keys are kept for decryption of existing values
+func (ring *KeyRing) AddKey(id string, key []byte) error {
+	if id == "" || strings.Contains(id, ":") {
+		return errors.New(fmt.Sprintf("invalid key id `%s`", id))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	ring.mutex.Lock()
+	defer ring.mutex.Unlock()
+	ring.keys[id] = aead
+	ring.primary = id
+	return nil
+}
+
+// Encrypt value of field with path e.g. server.password, path is authenticated
+// so encrypted value can not be moved to other field
+func (ring *KeyRing) Encrypt(path string, value string) (string, error) {
+	ring.mutex.RLock()
+	defer ring.mutex.RUnlock()
+	aead, ok := ring.keys[ring.primary]
+	if !ok {
+		return "", errors.New("key ring does not have keys")
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(path))
+	return encryptedPrefix + ring.primary + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt value of field with path
+func (ring *KeyRing) Decrypt(path string, value string) (string, error) {
+	if !isEncrypted(value) {
+		return "", errors.New("value is not encrypted")
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, encryptedPrefix), ":", 2)
+	if len(parts) != 2 {
+		return "", errors.New("invalid format of encrypted value")
+	}
+	ring.mutex.RLock()
+	aead, ok := ring.keys[parts[0]]
+	ring.mutex.RUnlock()
+	if !ok {
+		return "", errors.New(fmt.Sprintf("unknown key `%s`", parts[0]))
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("invalid format of encrypted value")
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(path))
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("authentication of value with key `%s` failed", parts[0]))
+	}
+	return string(plain), nil
+}
+
+// Check if value has prefix of encrypted values
+func isEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// Decrypt encrypted string value of field with path, non string fields get value parsed by kind of field
+func (loader *valueLoader) decryptValue(field reflectionField, s string, path string) (interface{}, bool) {
+	if !isEncrypted(s) {
+		return s, false
+	}
+	var err error
+	if loader.keyRing == nil {
+		err = errors.New("key ring is not set")
+	} else if plain, decryptErr := loader.keyRing.Decrypt(path, s); decryptErr == nil {
+		return stringValue(field.fieldType, plain), true
+	} else {
+		err = decryptErr
+	}
+	loader.addFailure(&ValidationError{
+		Path:     path,
+		Expected: field.fieldType.Kind(),
+		Received: "string",
+		Code:     DecryptionCode,
+		Err:      err,
+		secret:   field.isSecret,
+	})
+	return s, false
+}
@@ -0,0 +1,161 @@
+package reflector
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+func TestKeyRing(t *testing.T) {
+	ring := NewKeyRing()
+	if _, err := ring.Encrypt("password", "p4ss"); err == nil {
+		t.Error("there must be an error for empty key ring")
+	}
+	if err := ring.AddKey("old", bytes.Repeat([]byte{1}, 16)); err != nil {
+		t.Fatal(err)
+	}
+	old, err := ring.Encrypt("password", "p4ss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(old, "enc:v1:old:") || strings.Contains(old, "p4ss") {
+		t.Errorf("invalid encrypted value: %s", old)
+	}
+
+	// rotation keeps old keys for decryption
+	if err := ring.AddKey("new", bytes.Repeat([]byte{2}, 32)); err != nil {
+		t.Fatal(err)
+	}
+	current, err := ring.Encrypt("password", "p4ss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(current, "enc:v1:new:") {
+		t.Errorf("value must be encrypted by the last key: %s", current)
+	}
+	for _, value := range []string{old, current} {
+		if plain, err := ring.Decrypt("password", value); err != nil || plain != "p4ss" {
+			t.Errorf("invalid decryption of %s: %s %v", value, plain, err)
+		}
+	}
+
+	// path is authenticated
+	if _, err := ring.Decrypt("token", current); err == nil {
+		t.Error("there must be an error for value of other field")
+	}
+	for _, value := range []string{"enc:v1:", "enc:v1:other:AAAA", "enc:v1:new:!!!", "enc:v1:new:AAAA"} {
+		if _, err := ring.Decrypt("password", value); err == nil {
+			t.Errorf("there must be an error for %s", value)
+		}
+	}
+	for _, id := range []string{"", "a:b"} {
+		if err := ring.AddKey(id, bytes.Repeat([]byte{1}, 16)); err == nil {
+			t.Errorf("there must be an error for key id `%s`", id)
+		}
+	}
+	if err := ring.AddKey("short", []byte{1, 2, 3}); err == nil {
+		t.Error("there must be an error for invalid key size")
+	}
+}
+
+func TestEncryptedValues(t *testing.T) {
+	type Config struct {
+		Password string `config:"password is_secret"`
+		Database struct {
+			Port   *uint16           `config:"port"`
+			Tokens map[string]string `config:"tokens"`
+		} `config:"database"`
+	}
+	config := &Config{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ring := NewKeyRing()
+	if err := ring.AddKey("main", bytes.Repeat([]byte{1}, 32)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Encrypt("password", "p4ss"); err == nil {
+		t.Error("there must be an error without key ring")
+	}
+	r.SetKeyRing(ring)
+
+	values := map[string]string{}
+	for path, value := range map[string]string{"password": "p4ss", "database.port": "5432",
+		"database.tokens.api": "t0ken"} {
+		if values[path], err = r.Encrypt(path, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, path := range []string{"database", "missing", "database.tokens"} {
+		if _, err := r.Encrypt(path, "value"); err == nil {
+			t.Errorf("there must be an error for path %s", path)
+		}
+	}
+
+	provider := providers.NewJsonDataProvider([]byte(`{"password":"` + values["password"] +
+		`","database":{"port":"` + values["database.port"] + `","tokens":{"api":"` +
+		values["database.tokens.api"] + `"}}}`))
+	if _, err := r.SetValues(provider); err != nil {
+		t.Fatal(err)
+	}
+	if config.Password != "p4ss" || config.Database.Port == nil || *config.Database.Port != 5432 ||
+		config.Database.Tokens["api"] != "t0ken" {
+		t.Errorf("invalid values: %+v", config)
+	}
+
+	// value moved to other field
+	provider = providers.NewJsonDataProvider([]byte(`{"database":{"tokens":{"web":"` +
+		values["database.tokens.api"] + `"}}}`))
+	_, err = r.SetValues(provider)
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 || errs[0].Code != DecryptionCode || errs[0].Path != "database.tokens.web" {
+		t.Errorf("invalid errors: %v", err)
+	}
+}
+
+func TestEncryptedValuesInterpolation(t *testing.T) {
+	type Config struct {
+		Password string `config:"password is_secret"`
+		URL      string `config:"url"`
+		Copy     string `config:"copy"`
+	}
+	config := &Config{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ring := NewKeyRing()
+	if err := ring.AddKey("main", bytes.Repeat([]byte{1}, 32)); err != nil {
+		t.Fatal(err)
+	}
+	r.SetKeyRing(ring)
+	password, err := r.Encrypt("password", "p4ss")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := providers.NewJsonDataProvider([]byte(`{"password":"` + password +
+		`","url":"user:${password}@host","copy":"${password}"}`))
+	if _, err := r.SetValues(provider); err != nil {
+		t.Fatal(err)
+	}
+	if config.Password != "p4ss" || config.URL != "user:p4ss@host" || config.Copy != "p4ss" {
+		t.Errorf("invalid values: %+v", config)
+	}
+
+	// value which can not be decrypted is not copied to other fields
+	other := NewKeyRing()
+	if err := other.AddKey("main", bytes.Repeat([]byte{2}, 32)); err != nil {
+		t.Fatal(err)
+	}
+	r.SetKeyRing(other)
+	_, err = r.SetValues(provider)
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 3 || errs[0].Code != DecryptionCode || errs[1].Code != ReferenceCode ||
+		errs[2].Code != ReferenceCode {
+		t.Errorf("invalid errors: %v", err)
+	}
+}
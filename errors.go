@@ -0,0 +1,135 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Code of validation error
+type ErrorCode int
+
+const (
+	RequiredCode      ErrorCode = iota // value is required
+	InvalidTypeCode                    // value has invalid type
+	OverflowCode                       // value overflows field type
+	TruncationCode                     // value is truncated by conversion
+	InvalidFormatCode                  // value has invalid format e.g. datetime
+	ReferenceCode                      // reference in value can not be resolved
+	DecryptionCode                     // encrypted value can not be decrypted
+	RedactedCode                       // secret value is redacted by Export
+)
+
+var errorCodeNames = map[ErrorCode]string{
+	RequiredCode:      "required",
+	InvalidTypeCode:   "invalid_type",
+	OverflowCode:      "overflow",
+	TruncationCode:    "truncation",
+	InvalidFormatCode: "invalid_format",
+	ReferenceCode:     "reference",
+	DecryptionCode:    "decryption",
+	RedactedCode:      "redacted",
+}
+
+func (code ErrorCode) String() string {
+	if name, ok := errorCodeNames[code]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Error of field value
+type ValidationError struct {
+	Path      string       // path of field e.g. server.params[2].label
+	Expected  reflect.Kind // expected kind of value
+	Received  string       // type of received value, empty if value is missing
+	Code      ErrorCode
+	Reference string // failed reference e.g. ${env:HOME}
+	Err       error  // error of resolver or decryption
+	value     interface{}
+	secret    bool
+}
+
+// Received value is not revealed for secret fields
+func (err *ValidationError) Error() string {
+	value := err.value
+	if err.secret {
+		value = secretMask
+	}
+	switch err.Code {
+	case RequiredCode:
+		return fmt.Sprintf("value for field `%s` is required", err.Path)
+	case OverflowCode:
+		return fmt.Sprintf("value %v overflows field `%s` of type %s", value, err.Path, err.Expected)
+	case TruncationCode:
+		return fmt.Sprintf("value %v for field `%s` is truncated by conversion to %s", value, err.Path,
+			err.Expected)
+	case InvalidFormatCode:
+		return fmt.Sprintf("invalid format of value `%v` for field `%s`", value, err.Path)
+	case ReferenceCode:
+		return fmt.Sprintf("can not resolve reference `%s` for field `%s`: %s", err.Reference, err.Path, err.Err)
+	case DecryptionCode:
+		return fmt.Sprintf("can not decrypt value for field `%s`: %s", err.Path, err.Err)
+	case RedactedCode:
+		return fmt.Sprintf("value for field `%s` is redacted", err.Path)
+	}
+	return fmt.Sprintf("invalid type `%s` for field `%s` expected %s", err.Received, err.Path, err.Expected)
+}
+
+func (err *ValidationError) Unwrap() error {
+	return err.Err
+}
+
+// All errors found while setting values
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Path of nested field
+func fieldPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// Path of slice element
+func indexPath(path string, index int) string {
+	return fmt.Sprintf("%s[%d]", path, index)
+}
+
+// Error of struct schema
+type SchemaError struct {
+	Field   string // path of go field e.g. Server.Params.Label
+	Tag     string // value of tag
+	Message string
+	Err     error // error of tag parser
+}
+
+func (err *SchemaError) Error() string {
+	if err.Err != nil {
+		return fmt.Sprintf("field `%s`: %s: %s", err.Field, err.Message, err.Err)
+	}
+	return fmt.Sprintf("field `%s`: %s", err.Field, err.Message)
+}
+
+func (err *SchemaError) Unwrap() error {
+	return err.Err
+}
+
+// All errors found in struct schema
+type SchemaErrors []*SchemaError
+
+func (errs SchemaErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
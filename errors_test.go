@@ -0,0 +1,111 @@
+package reflector
+
+import (
+	"reflect"
+	"testing"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+func TestValidationErrors(t *testing.T) {
+	type Config struct {
+		Name   string `config:"name is_required"`
+		Server struct {
+			Port   uint8 `config:"port"`
+			Params []struct {
+				Label string `config:"label is_required"`
+			} `config:"params"`
+		} `config:"server"`
+		Labels map[string]int `config:"labels"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider([]byte(`{"server":{"port":300,"params":[{"label":"a"},{"label":"b"},{}]},
+		"labels":{"first":1.5}}`))
+	_, err = r.SetValues(provider)
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("invalid type of error: %T", err)
+	}
+	expected := []ValidationError{
+		{Path: "name", Expected: reflect.String, Code: RequiredCode},
+		{Path: "server.port", Expected: reflect.Uint8, Received: "float64", Code: OverflowCode},
+		{Path: "server.params[2].label", Expected: reflect.String, Code: RequiredCode},
+		{Path: "labels.first", Expected: reflect.Int, Received: "float64", Code: TruncationCode},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("invalid number of errors: %s", errs)
+	}
+	for i, e := range expected {
+		if errs[i].Path != e.Path || errs[i].Expected != e.Expected || errs[i].Received != e.Received ||
+			errs[i].Code != e.Code {
+			t.Errorf("expected: %+v, actual: %+v", e, *errs[i])
+		}
+	}
+	if errs[2].Error() != "value for field `server.params[2].label` is required" {
+		t.Errorf("invalid message: %s", errs[2])
+	}
+}
+
+func TestPointerFieldErrors(t *testing.T) {
+	type Config struct {
+		Port    *int               `config:"port is_required"`
+		Timeout *float64           `config:"timeout"`
+		Hosts   []*string          `config:"hosts"`
+		Limits  map[string]*uint16 `config:"limits"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider([]byte(`{"timeout":"5s","hosts":[1],"limits":{"cpu":70000}}`))
+	_, err = r.SetValues(provider)
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("invalid type of error: %T", err)
+	}
+	expected := []ValidationError{
+		{Path: "port", Expected: reflect.Int, Code: RequiredCode},
+		{Path: "timeout", Expected: reflect.Float64, Received: "string", Code: InvalidTypeCode},
+		{Path: "hosts[0]", Expected: reflect.String, Received: "float64", Code: InvalidTypeCode},
+		{Path: "limits.cpu", Expected: reflect.Uint16, Received: "float64", Code: OverflowCode},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("invalid number of errors: %s", errs)
+	}
+	for i, e := range expected {
+		if errs[i].Path != e.Path || errs[i].Expected != e.Expected || errs[i].Received != e.Received ||
+			errs[i].Code != e.Code {
+			t.Errorf("expected: %+v, actual: %+v", e, *errs[i])
+		}
+	}
+}
+
+func TestInvalidTypeError(t *testing.T) {
+	type Config struct {
+		Server struct {
+			Name string `config:"name"`
+		} `config:"server"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = r.SetValues(providers.NewJsonDataProvider([]byte(`{"server":"localhost"}`)))
+	if errs, ok := err.(ValidationErrors); !ok || len(errs) != 1 {
+		t.Fatalf("invalid error: %v", err)
+	} else if errs[0].Error() != "invalid type `string` for field `server` expected struct" {
+		t.Errorf("invalid message: %s", errs[0])
+	}
+}
+
+func TestErrorCodeString(t *testing.T) {
+	if RequiredCode.String() != "required" {
+		t.Error("invalid name of code")
+	}
+	if ErrorCode(100).String() != "unknown" {
+		t.Error("invalid name of unknown code")
+	}
+}
@@ -0,0 +1,95 @@
+package reflector
+
+import (
+	"reflect"
+)
+
+// Export current values of reflection source through provider, secret values are redacted
+// and redacted values can not be loaded back, use Dump for full copy of values
+func (reflection *Reflector) Export(provider DataProvider) (interface{}, error) {
+	return reflection.export(provider, true)
+}
+
+// Dump current values of reflection source through provider including secret values,
+// result can be loaded back by SetValues
+func (reflection *Reflector) Dump(provider DataProvider) (interface{}, error) {
+	return reflection.export(provider, false)
+}
+
+// Export values through provider, secret values are replaced by mask if redact is set
+func (reflection *Reflector) export(provider DataProvider, redact bool) (interface{}, error) {
+	raw := exportFieldsValues(reflect.ValueOf(reflection.source).Elem(), reflection.fields, redact)
+
+	if err := provider.Unload(raw); err != nil {
+		return nil, err
+	}
+
+	return provider.Data(), nil
+}
+
+// Export values of struct fields by config names
+func exportFieldsValues(value reflect.Value, fields []reflectionField, redact bool) map[string]interface{} {
+	raw := map[string]interface{}{}
+	for _, field := range fields {
+		if v, ok := exportFieldValue(value.Field(field.fieldIndex), field, redact); ok {
+			raw[field.configField.Name] = v
+		}
+	}
+	return raw
+}
+
+// Export value of field, nil pointers, maps and slices are skipped
+func exportFieldValue(value reflect.Value, field reflectionField, redact bool) (interface{}, bool) {
+	if redact && field.isSecret && isScalarType(value.Type()) {
+		if value.Kind() == reflect.Ptr && value.IsNil() {
+			return nil, false
+		}
+		return secretMask, true
+	}
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil, false
+		}
+		return exportFieldValue(value.Elem(), field, redact)
+	case reflect.Struct:
+		if value.Type() == timeType {
+			return value.Interface(), true
+		}
+		return exportFieldsValues(value, field.fields, redact), true
+	case reflect.String:
+		return value.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	case reflect.Bool:
+		return value.Bool(), true
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, false
+		}
+		raw := map[string]interface{}{}
+		iter := value.MapRange()
+		for iter.Next() {
+			if v, ok := exportFieldValue(iter.Value(), field, redact); ok {
+				raw[iter.Key().String()] = v
+			}
+		}
+		return raw, true
+	case reflect.Slice:
+		if value.IsNil() {
+			return nil, false
+		}
+		raw := make([]interface{}, 0, value.Len())
+		for index := 0; index < value.Len(); index++ {
+			if v, ok := exportFieldValue(value.Index(index), field, redact); ok {
+				raw = append(raw, v)
+			}
+		}
+		return raw, true
+	}
+	return nil, false
+}
@@ -0,0 +1,56 @@
+package reflector
+
+import (
+	"testing"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+func TestExport(t *testing.T) {
+	type Config struct {
+		Host    string  `config:"host is_required"`
+		Port    uint16  `config:"port has_default 8080"`
+		Percent float32 `config:"percent"`
+		Backup  *string `config:"backup"`
+		Server  struct {
+			Name   string `config:"name"`
+			Params []struct {
+				Label string `config:"label"`
+			} `config:"params"`
+		} `config:"server"`
+		Labels map[string]string `config:"labels"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider([]byte(`{"host":"localhost","percent":0.5,
+		"server":{"name":"main","params":[{"label":"a"}]},"labels":{"env":"prod"}}`))
+	if _, err := r.SetValues(provider); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"host":"localhost","labels":{"env":"prod"},"percent":0.5,"port":8080,` +
+		`"server":{"name":"main","params":[{"label":"a"}]}}`
+	data, err := r.Export(providers.NewJsonDataProvider(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data.([]byte)) != expected {
+		t.Errorf("invalid export: %s", data)
+	}
+
+	// round trip
+	copied, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := copied.SetValues(providers.NewJsonDataProvider(data.([]byte))); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := copied.Export(providers.NewJsonDataProvider(nil)); err != nil {
+		t.Error(err)
+	} else if string(data.([]byte)) != expected {
+		t.Errorf("invalid export after round trip: %s", data)
+	}
+}
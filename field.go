@@ -3,73 +3,62 @@ package reflector
 import (
 	"stash.abc.ee/micro/reflector/parser"
 	"reflect"
-	"unicode"
+	"strings"
 	"fmt"
+	"time"
 )
 
+// Key used in template for values of map fields
+const mapKeyPlaceholder = "{key}"
+
+// Datetime fields are set as values instead of structs
+var timeType = reflect.TypeOf(time.Time{})
+
 type reflectionField struct {
 	configField *parser.ConfigField
-	hasValue    bool
 	fieldIndex  int
 	fieldType   reflect.Type
+	isPointer   bool
 	isStruct    bool
+	isSecret    bool
+	dependsOn   int // index of field from is_required_if in fields of struct
 	fields      []reflectionField
+	setter      valueSetter      // setter for kind of field type
+	elem        *reflectionField // field of elements for slices and maps
 }
 
-// get string information for field
-func (reflectionField reflectionField) GetInfo() interface{} {
-	var s string
-
-	// detect type
-	switch kind := reflectionField.fieldType.Kind(); kind {
-
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		s = "int"
-	case reflect.Float32, reflect.Float64:
-		s = "float"
-	case reflect.String:
-		s = "string"
-	case reflect.Slice:
-		// use slice
-		value := []interface{}{}
-		for _, field := range reflectionField.fields {
-			value = append(value, field.GetInfo())
-		}
-		return value
-	case reflect.Struct:
-		// use map of strings
-		value := map[string]interface{}{}
-		for _, field := range reflectionField.fields {
-			value[field.configField.Name] = field.GetInfo()
-		}
-		return value
-	}
-
-	// processing default value
-	if reflectionField.configField.IsRequired {
-		s += " required"
-	}
-	if v := reflectionField.configField.DefaultValue; v != nil {
-		s += fmt.Sprintf(" default %v", v)
-	}
-	// processing is_required and depends on
-	return s
+// get template information for field
+func (field reflectionField) GetInfo() interface{} {
+	return field.info().Template()
 }
 
-// Processing tags
-func processingTags(st reflect.Type, tagName string) []reflectionField {
+// Processing tags, all problems of schema are returned as SchemaErrors
+func processingTags(st reflect.Type, tagName string) ([]reflectionField, SchemaErrors) {
 	if st.Kind() == reflect.Ptr {
 		st = st.Elem()
 	}
 	fields := []reflectionField{}
+	var schemaErrors SchemaErrors
 	for fieldIndex := 0; fieldIndex < st.NumField(); fieldIndex++ {
 		field := st.Field(fieldIndex)
-		if newField := processingField(field, tagName); newField != nil {
+		newField, errs := processingField(field, tagName)
+		schemaErrors = append(schemaErrors, errs...)
+		if newField != nil {
+			duplicate := false
 			for _, field := range fields {
 				if newField.configField.Name == field.configField.Name {
-					panic(fmt.Sprintf("there are already field with name `%s`", newField.configField.Name))
+					duplicate = true
+					break
 				}
 			}
+			if duplicate {
+				schemaErrors = append(schemaErrors, &SchemaError{
+					Field:   field.Name,
+					Tag:     field.Tag.Get(tagName),
+					Message: fmt.Sprintf("there are already field with name `%s`", newField.configField.Name),
+				})
+				continue
+			}
 			newField.fieldIndex = fieldIndex
 			fields = append(fields, *newField)
 		}
@@ -81,36 +70,59 @@ func processingTags(st reflect.Type, tagName string) []reflectionField {
 			for ii, df := range fields {
 				if ii != i {
 					if df.configField.Name == name {
+						fields[i].dependsOn = ii
 						found = true
 						break
 					}
 				}
 			}
 			if !found {
-				panic(fmt.Sprintf("field `%s` depends on `%s` which does not exists in struct", f.configField.Name, name))
+				field := st.Field(f.fieldIndex)
+				schemaErrors = append(schemaErrors, &SchemaError{
+					Field:   field.Name,
+					Tag:     field.Tag.Get(tagName),
+					Message: fmt.Sprintf("field `%s` depends on `%s` which does not exists in struct", f.configField.Name, name),
+				})
 			}
 		}
 	}
-	return fields
+	return fields, schemaErrors
 }
 
-// Internal processing of field
-func processingField(field reflect.StructField, tagName string) *reflectionField {
+// Internal processing of field, returns nil for fields without tag
+func processingField(field reflect.StructField, tagName string) (*reflectionField, SchemaErrors) {
+	tag, ok := field.Tag.Lookup(tagName)
+	if !ok || strings.TrimSpace(tag) == "" {
+		return nil, nil
+	}
+	schemaError := func(message string, err error) SchemaErrors {
+		return SchemaErrors{&SchemaError{Field: field.Name, Tag: tag, Message: message, Err: err}}
+	}
+
 	reflectionField := reflectionField{}
-	if field.Type.Kind() == reflect.Ptr {
-		panic("pointer is not allowed")
+	// pointers are optional values, field keeps type of the element
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+		if fieldType.Kind() == reflect.Ptr {
+			return nil, schemaError("pointer to pointer is not allowed", nil)
+		}
+		reflectionField.isPointer = true
+	}
+	reflectionField.fieldType = fieldType
+	for t := fieldType; t != baseType(t); t = t.Elem() {
+		if t.Kind() == reflect.Map && t.Key().Kind() != reflect.String {
+			return nil, schemaError("map must have string keys", nil)
+		}
 	}
-	reflectionField.fieldType = field.Type
-	name := []rune(field.Name)
-	name[0] = unicode.ToLower(name[0])
-	if string(name) == field.Name {
-		panic(fmt.Sprintf("field %s is unexported", field.Name))
+	if field.PkgPath != "" {
+		return nil, schemaError("field is unexported", nil)
 	}
 
 	// processing tag
-	p := parser.NewParser(field.Tag.Get(tagName))
+	p := parser.NewParser(tag)
 	if configField, err := p.Parse(); err != nil {
-		return nil // return empty field
+		return nil, schemaError(fmt.Sprintf("invalid tag `%s`", tag), err)
 	} else {
 		reflectionField.configField = configField
 		if reflectionField.configField.Name == "" {
@@ -119,16 +131,68 @@ func processingField(field reflect.StructField, tagName string) *reflectionField
 	}
 
 	// Processing struct and slices
-	if field.Type.Kind() == reflect.Struct {
+	var errs SchemaErrors
+	if fieldType == timeType || baseType(fieldType) == timeType {
+		// datetime values do not have nested fields
+	} else if fieldType.Kind() == reflect.Struct {
 		// processing struct
 		reflectionField.isStruct = true
-		reflectionField.fields = processingTags(field.Type, tagName)
+		reflectionField.fields, errs = processingTags(fieldType, tagName)
+
+	} else if baseType := baseType(fieldType); baseType.Kind() == reflect.Struct {
+		// processing slices and maps of struct (or pointers to struct)
+		reflectionField.fields, errs = processingTags(baseType, tagName)
+	}
+	// secret values are not revealed in nested fields and elements
+	reflectionField.isSecret = reflectionField.configField.IsSecret || baseType(fieldType) == secretType
+	if reflectionField.isSecret {
+		markSecret(reflectionField.fields)
+	}
+	reflectionField.bind()
+	// nested errors are reported with path of go fields
+	for _, err := range errs {
+		err.Field = field.Name + "." + err.Field
+	}
+
+	return &reflectionField, errs
+
+}
 
-	} else if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct {
-		// processing slice of struct
-		reflectionField.fields = processingTags(field.Type.Elem(), tagName)
+// Element type of slices and maps without pointers
+func baseType(fieldType reflect.Type) reflect.Type {
+	for {
+		switch fieldType.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			fieldType = fieldType.Elem()
+		default:
+			return fieldType
+		}
 	}
+}
 
-	return &reflectionField
+// Field for elements of slice or map
+func (field reflectionField) elemField() reflectionField {
+	return *field.elem
+}
 
+// Precompute setter and fields of elements for binding of values
+func (field *reflectionField) bind() {
+	field.setter = setterOf(field.fieldType)
+	if kind := field.fieldType.Kind(); kind != reflect.Slice && kind != reflect.Map {
+		return
+	}
+	fieldType := field.fieldType.Elem()
+	isPointer := fieldType.Kind() == reflect.Ptr
+	if isPointer {
+		fieldType = fieldType.Elem()
+	}
+	field.elem = &reflectionField{
+		configField: &parser.ConfigField{Name: field.configField.Name},
+		fieldType:   fieldType,
+		isPointer:   isPointer,
+		isStruct:    fieldType.Kind() == reflect.Struct && fieldType != timeType,
+		isSecret:    field.isSecret,
+		fields:      field.fields,
+	}
+	field.elem.bind()
 }
@@ -1,6 +1,7 @@
 package reflector
 
 import (
+	"strings"
 	"testing"
 	"reflect"
 )
@@ -15,8 +16,8 @@ func TestProcessingField(t *testing.T) {
 	}{}
 
 	// Test fist field
-	nameField := processingField(reflect.TypeOf(v).Field(0), "config")
-	fieldField := processingField(reflect.TypeOf(v).Field(1), "config")
+	nameField, _ := processingField(reflect.TypeOf(v).Field(0), "config")
+	fieldField, _ := processingField(reflect.TypeOf(v).Field(1), "config")
 
 	if nameField.fieldType.Kind() != reflect.String {
 		t.Error("field type is string")
@@ -29,41 +30,84 @@ func TestProcessingField(t *testing.T) {
 }
 
 func TestProcessingTags(t *testing.T) {
-
-	defer func() {
-		if err := recover(); err == nil {
-			t.Error("there must be a panic")
-		}
-	}()
-
 	type BadConfig struct {
 		SslCert string `config:"ssl_cert is_required_if ssl_mode has_value true"`
 	}
 
 	typeOf :=reflect.TypeOf(BadConfig{})
-	_ = processingTags(typeOf, "config")
-
+	if _, errs := processingTags(typeOf, "config"); len(errs) != 1 {
+		t.Error("there must be an error")
+	}
 }
 
 func TestUsingPointer(t *testing.T) {
 	type Config struct {
 		Name string `config:"name is_requred"`
 	}
-	if fields := processingTags(reflect.TypeOf(&Config{}), "config"); len(fields) != 1 {
+	if fields, _ := processingTags(reflect.TypeOf(&Config{}), "config"); len(fields) != 1 {
 		t.Fatal("there must be one field")
 	}
 }
 
 func TestDublicatesInTags(t *testing.T) {
-	defer func() {
-		if err := recover(); err == nil {
-			t.Error("there must be a panic")
-		}
-	}()
-
 	type StructWithDublicateTags struct {
 		Name string `config:"name"`
 		Label string `config:"name is_requred"`
 	}
-	processingTags(reflect.TypeOf(StructWithDublicateTags{}), "config")
+	if _, errs := processingTags(reflect.TypeOf(StructWithDublicateTags{}), "config"); len(errs) != 1 {
+		t.Error("there must be an error")
+	} else if errs[0].Field != "Label" {
+		t.Errorf("invalid field of error: %s", errs[0].Field)
+	}
+}
+
+func TestPointerToPointer(t *testing.T) {
+	type Config struct {
+		Name **string `config:"name"`
+	}
+	if _, errs := processingTags(reflect.TypeOf(Config{}), "config"); len(errs) != 1 {
+		t.Error("there must be an error")
+	}
+}
+
+func TestMapWithInvalidKey(t *testing.T) {
+	type Config struct {
+		Ports map[int]string `config:"ports"`
+	}
+	if _, errs := processingTags(reflect.TypeOf(Config{}), "config"); len(errs) != 1 {
+		t.Error("there must be an error")
+	}
+}
+
+func TestSchemaErrors(t *testing.T) {
+	type Config struct {
+		Name    string `config:"name has_default"`
+		private string `config:"private"`
+		Skipped string
+		hidden  string
+		Server  struct {
+			Host string `config:"host"`
+			Port int    `config:"host"`
+		} `config:"server"`
+	}
+	fields, errs := processingTags(reflect.TypeOf(Config{}), "config")
+	if len(fields) != 1 {
+		t.Errorf("there must be one valid field: %d", len(fields))
+	}
+	if len(errs) != 3 {
+		t.Fatalf("there must be 3 errors: %s", errs)
+	}
+	expected := []string{
+		"field `Name`: invalid tag `name has_default`: has_default must has value",
+		"field `private`: field is unexported",
+		"field `Server.Port`: there are already field with name `host`",
+	}
+	for i, message := range expected {
+		if errs[i].Error() != message {
+			t.Errorf("expected: %s, actual: %s", message, errs[i])
+		}
+	}
+	if !strings.Contains(errs.Error(), "\n") {
+		t.Error("all errors must be in message")
+	}
 }
@@ -0,0 +1,197 @@
+package reflector
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Provider of command line flags registered for reflector fields,
+// only explicitly set flags are loaded so they can override other providers
+type FlagDataProvider struct {
+	flagSet *flag.FlagSet
+	values  map[string]*flagValue
+	data    map[string]interface{}
+}
+
+// Value of flag for field
+type flagValue struct {
+	field reflectionField
+	raw   string
+	value interface{}
+}
+
+// Register flag with dotted name (e.g. server.name) for each field on flag set
+func (reflection *Reflector) Flags(flagSet *flag.FlagSet) *FlagDataProvider {
+	provider := &FlagDataProvider{
+		flagSet: flagSet,
+		values:  map[string]*flagValue{},
+	}
+	provider.register(reflection.fields, "")
+	return provider
+}
+
+// Register flags for fields of struct
+func (provider *FlagDataProvider) register(fields []reflectionField, path string) {
+	for _, field := range fields {
+		name := fieldPath(path, field.configField.Name)
+		if field.isStruct {
+			provider.register(field.fields, name)
+			continue
+		}
+		if !isFlagType(field.fieldType) {
+			// slices of structs and maps of structs can not be set by flag
+			continue
+		}
+		value := &flagValue{field: field}
+		if v := field.configField.DefaultValue; v != nil && !field.isSecret {
+			value.raw = formatFlagValue(v)
+		}
+		provider.values[name] = value
+		provider.flagSet.Var(value, name, fmt.Sprintf("%v", field.GetInfo()))
+	}
+}
+
+func (provider *FlagDataProvider) Load() (map[string]interface{}, error) {
+	if !provider.flagSet.Parsed() {
+		return nil, errors.New("flags are not parsed")
+	}
+	data := map[string]interface{}{}
+	provider.flagSet.Visit(func(f *flag.Flag) {
+		value, ok := provider.values[f.Name]
+		if !ok {
+			return
+		}
+		// create nested objects for dotted name
+		object := data
+		names := strings.Split(f.Name, ".")
+		for _, name := range names[:len(names)-1] {
+			if _, ok := object[name].(map[string]interface{}); !ok {
+				object[name] = map[string]interface{}{}
+			}
+			object = object[name].(map[string]interface{})
+		}
+		object[names[len(names)-1]] = value.value
+	})
+	return data, nil
+}
+
+// Unloaded data is kept by provider and returned by Data
+func (provider *FlagDataProvider) Unload(data map[string]interface{}) error {
+	provider.data = data
+	return nil
+}
+
+func (provider *FlagDataProvider) Data() interface{} {
+	return provider.data
+}
+
+func (value *flagValue) String() string {
+	if value == nil {
+		return ""
+	}
+	return value.raw
+}
+
+// Parse value of flag according to type of field
+func (value *flagValue) Set(raw string) error {
+	v, err := parseFlagValue(raw, value.field.fieldType)
+	if err != nil {
+		return err
+	}
+	value.raw, value.value = raw, v
+	return nil
+}
+
+// Bool flags can be set without value
+func (value *flagValue) IsBoolFlag() bool {
+	return value.field.fieldType.Kind() == reflect.Bool
+}
+
+// Check if type can be set by flag: scalars, slices and maps of scalars
+func isFlagType(fieldType reflect.Type) bool {
+	switch fieldType.Kind() {
+	case reflect.Slice, reflect.Map:
+		return isScalarType(fieldType.Elem())
+	}
+	return isScalarType(fieldType)
+}
+
+func isScalarType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Parse value of flag, slices are separated by comma, maps are comma separated key=value pairs
+func parseFlagValue(raw string, fieldType reflect.Type) (interface{}, error) {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.String:
+		return raw, nil
+	case reflect.Bool:
+		return strconv.ParseBool(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(raw, 10, fieldType.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.ParseUint(raw, 10, fieldType.Bits())
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(raw, fieldType.Bits())
+	case reflect.Slice:
+		result := []interface{}{}
+		if raw == "" {
+			return result, nil
+		}
+		for _, item := range strings.Split(raw, ",") {
+			v, err := parseFlagValue(strings.TrimSpace(item), fieldType.Elem())
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, v)
+		}
+		return result, nil
+	case reflect.Map:
+		result := map[string]interface{}{}
+		if raw == "" {
+			return result, nil
+		}
+		for _, item := range strings.Split(raw, ",") {
+			pair := strings.SplitN(item, "=", 2)
+			if len(pair) != 2 {
+				return nil, errors.New(fmt.Sprintf("invalid pair `%s` expected key=value", item))
+			}
+			v, err := parseFlagValue(strings.TrimSpace(pair[1]), fieldType.Elem())
+			if err != nil {
+				return nil, err
+			}
+			result[strings.TrimSpace(pair[0])] = v
+		}
+		return result, nil
+	}
+	return nil, errors.New(fmt.Sprintf("type %s is not supported by flags", fieldType))
+}
+
+// Format default value of tag in flag syntax
+func formatFlagValue(v interface{}) string {
+	if slice, ok := toSlice(v); ok {
+		items := make([]string, 0, len(slice))
+		for _, item := range slice {
+			items = append(items, fmt.Sprintf("%v", item))
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,96 @@
+package reflector
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+func TestFlags(t *testing.T) {
+	type Config struct {
+		Host   string            `config:"host is_required"`
+		Port   uint16            `config:"port has_default 8080"`
+		Debug  bool              `config:"debug"`
+		Ratio  *float64          `config:"ratio"`
+		Tags   []string          `config:"tags has_default ['a', 'b']"`
+		Labels map[string]string `config:"labels"`
+		Server struct {
+			Name   string `config:"name"`
+			Params []struct {
+				Label string `config:"label"`
+			} `config:"params"`
+		} `config:"server"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := r.Flags(flagSet)
+
+	for _, name := range []string{"host", "port", "debug", "ratio", "tags", "labels", "server.name"} {
+		if flagSet.Lookup(name) == nil {
+			t.Errorf("there is no flag %s", name)
+		}
+	}
+	if flagSet.Lookup("server.params") != nil {
+		t.Error("slice of structs can not be a flag")
+	}
+	if f := flagSet.Lookup("port"); f.DefValue != "8080" || f.Usage != "uint default 8080" {
+		t.Errorf("invalid flag for port: %+v", f)
+	}
+	if f := flagSet.Lookup("tags"); f.DefValue != "a,b" {
+		t.Errorf("invalid default of tags: %s", f.DefValue)
+	}
+
+	if _, err := flags.Load(); err == nil {
+		t.Error("there must be an error before parsing")
+	}
+	err = flagSet.Parse([]string{"--host", "localhost", "--debug", "--ratio=0.5", "--labels", "env=prod,tier=web",
+		"--server.name", "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// file values are overridden by flags
+	file := providers.NewJsonDataProvider([]byte(`{"host":"file","port":9090,"server":{"name":"file"}}`))
+	if config, err := r.SetValues(providers.NewLayeredDataProvider(file, flags)); err != nil {
+		t.Error(err)
+	} else {
+		config := config.(*Config)
+		if config.Host != "localhost" || config.Port != 9090 || !config.Debug || *config.Ratio != 0.5 {
+			t.Errorf("invalid values: %+v", config)
+		}
+		if config.Server.Name != "main" || config.Labels["tier"] != "web" || len(config.Tags) != 2 {
+			t.Errorf("invalid values: %+v", config)
+		}
+	}
+}
+
+func TestInvalidFlags(t *testing.T) {
+	type Config struct {
+		Port   uint8          `config:"port"`
+		Labels map[string]int `config:"labels"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := [][]string{
+		{"--port", "256"},
+		{"--port", "-1"},
+		{"--labels", "env"},
+		{"--labels", "env=prod"},
+	}
+	for _, test := range tests {
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		flagSet.SetOutput(&bytes.Buffer{})
+		r.Flags(flagSet)
+		if err := flagSet.Parse(test); err == nil {
+			t.Errorf("there must be an error for %s", strings.Join(test, " "))
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+// Kinds of field values in FieldInfo, the same as kinds of providers.Schema
+const (
+	IntKind      = providers.IntKind
+	UintKind     = providers.UintKind
+	FloatKind    = providers.FloatKind
+	StringKind   = providers.StringKind
+	BoolKind     = providers.BoolKind
+	DatetimeKind = providers.DatetimeKind
+	ObjectKind   = providers.ObjectKind
+	ArrayKind    = providers.ArrayKind
+	MapKind      = providers.MapKind
+)
+
+// Information about field of reflection source
+type FieldInfo struct {
+	Name           string      // config name, empty for elements of arrays and maps
+	GoType         string      // type of go field e.g. *int or []string
+	Kind           string      // kind of value e.g. int or object
+	Optional       bool        // field is a pointer which is nil without value
+	Required       bool        // value is required
+	Secret         bool        // value is not revealed
+	Default        interface{} // default value from tag, nil without default, redacted for secrets
+	DependsOn      string      // name of sibling field from is_required_if
+	DependsOnValue interface{} // value of sibling field from has_value, nil for any non zero value
+	Description    string      // description of field
+	Example        interface{} // example of value, nil without example, redacted for secrets
+	Unit           string      // unit of value e.g. ms
+	Elem           *FieldInfo  // information about elements of arrays and maps
+	Fields         []FieldInfo // information about fields of objects
+}
+
+// Get information about fields of reflection source
+func (reflection *Reflector) Schema() []FieldInfo {
+	return fieldsInfo(reflection.fields)
+}
+
+// Information about fields of struct
+func fieldsInfo(fields []reflectionField) []FieldInfo {
+	infos := make([]FieldInfo, 0, len(fields))
+	for _, field := range fields {
+		infos = append(infos, field.info())
+	}
+	return infos
+}
+
+// Information about field
+func (field reflectionField) info() FieldInfo {
+	info := FieldInfo{
+		Name:           field.configField.Name,
+		GoType:         field.fieldType.String(),
+		Optional:       field.isPointer,
+		Required:       field.configField.IsRequired,
+		Secret:         field.isSecret,
+		Default:        field.configField.DefaultValue,
+		DependsOn:      field.configField.DependsOn.ConfigFieldName,
+		DependsOnValue: field.configField.DependsOn.Value,
+		Description:    field.configField.Description,
+		Example:        field.configField.Example,
+		Unit:           field.configField.Unit,
+	}
+	if field.isPointer {
+		info.GoType = "*" + info.GoType
+	}
+	if info.Secret && info.Default != nil {
+		info.Default = secretMask
+	}
+	if info.Secret && info.Example != nil {
+		info.Example = secretMask
+	}
+
+	switch field.fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		info.Kind = IntKind
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		info.Kind = UintKind
+	case reflect.Float32, reflect.Float64:
+		info.Kind = FloatKind
+	case reflect.String:
+		info.Kind = StringKind
+	case reflect.Bool:
+		info.Kind = BoolKind
+	case reflect.Struct:
+		if field.fieldType == timeType {
+			info.Kind = DatetimeKind
+		} else {
+			info.Kind = ObjectKind
+			info.Fields = fieldsInfo(field.fields)
+		}
+	case reflect.Slice, reflect.Map:
+		info.Kind = ArrayKind
+		if field.fieldType.Kind() == reflect.Map {
+			info.Kind = MapKind
+		}
+		elem := field.elemField().info()
+		elem.Name = ""
+		info.Elem = &elem
+	}
+	return info
+}
+
+// Template of field e.g. "int required default 30 unit s example 10 - Timeout of requests",
+// objects are maps of fields, arrays are slices with template of elements
+// and maps have template of values under {key}
+func (info FieldInfo) Template() interface{} {
+	switch info.Kind {
+	case ObjectKind:
+		value := map[string]interface{}{}
+		for _, field := range info.Fields {
+			value[field.Name] = field.Template()
+		}
+		return value
+	case ArrayKind:
+		return []interface{}{info.Elem.Template()}
+	case MapKind:
+		return map[string]interface{}{mapKeyPlaceholder: info.Elem.Template()}
+	}
+
+	s := info.Kind
+	if info.Optional {
+		s += " optional"
+	}
+	if info.Required {
+		s += " required"
+	}
+	if info.Secret {
+		s += " secret"
+	}
+	if info.Default != nil {
+		s += fmt.Sprintf(" default %v", info.Default)
+	}
+	if info.Unit != "" {
+		s += " unit " + info.Unit
+	}
+	if info.Example != nil {
+		s += fmt.Sprintf(" example %v", info.Example)
+	}
+	if info.Description != "" {
+		s += " - " + info.Description
+	}
+	return s
+}
+
+// Schemas of fields for providers by config names
+func providerSchemas(infos []FieldInfo) map[string]*providers.Schema {
+	schemas := make(map[string]*providers.Schema, len(infos))
+	for _, info := range infos {
+		schemas[info.Name] = info.providerSchema()
+	}
+	return schemas
+}
+
+// Schema of field for providers, kinds of values without documentation
+func (info FieldInfo) providerSchema() *providers.Schema {
+	schema := &providers.Schema{Kind: info.Kind}
+	if info.Kind == ObjectKind {
+		schema.Fields = providerSchemas(info.Fields)
+	}
+	if info.Elem != nil {
+		schema.Elem = info.Elem.providerSchema()
+	}
+	return schema
+}
@@ -0,0 +1,110 @@
+package reflector
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+func TestSchema(t *testing.T) {
+	type Config struct {
+		Port    *uint16   `config:"port is_required has_default 8080"`
+		Token   string    `config:"token is_secret has_default 'x'"`
+		TLS     bool      `config:"tls"`
+		Cert    string    `config:"cert is_required_if tls has_value true"`
+		Tags    []string  `config:"tags"`
+		Started time.Time `config:"started"`
+		Servers map[string]struct {
+			Host string `config:"host"`
+		} `config:"servers"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := r.Schema()
+	if len(schema) != 7 {
+		t.Fatalf("invalid number of fields: %d", len(schema))
+	}
+
+	expected := []FieldInfo{
+		{Name: "port", GoType: "*uint16", Kind: UintKind, Optional: true, Required: true, Default: int64(8080)},
+		{Name: "token", GoType: "string", Kind: StringKind, Secret: true, Default: "******"},
+		{Name: "tls", GoType: "bool", Kind: BoolKind},
+		{Name: "cert", GoType: "string", Kind: StringKind, DependsOn: "tls", DependsOnValue: true},
+		{Name: "tags", GoType: "[]string", Kind: ArrayKind, Elem: &FieldInfo{GoType: "string", Kind: StringKind}},
+		{Name: "started", GoType: "time.Time", Kind: DatetimeKind},
+	}
+	for i, info := range expected {
+		if !reflect.DeepEqual(schema[i], info) {
+			t.Errorf("invalid info of field %d: %+v", i, schema[i])
+		}
+	}
+
+	servers := schema[6]
+	if servers.Kind != MapKind || servers.Elem == nil || servers.Elem.Kind != ObjectKind ||
+		len(servers.Elem.Fields) != 1 || servers.Elem.Fields[0].Name != "host" {
+		t.Errorf("invalid info of map: %+v", servers)
+	}
+	if template := servers.Template(); !reflect.DeepEqual(template, map[string]interface{}{
+		"{key}": map[string]interface{}{"host": "string"},
+	}) {
+		t.Errorf("invalid template of map: %v", template)
+	}
+	if template := schema[0].Template(); template != "uint optional required default 8080" {
+		t.Errorf("invalid template: %v", template)
+	}
+	if template := schema[4].Template(); !reflect.DeepEqual(template, []interface{}{"string"}) {
+		t.Errorf("invalid template of slice: %v", template)
+	}
+}
+
+func TestSchemaDocumentation(t *testing.T) {
+	type Config struct {
+		Timeout  uint   `config:"timeout has_default 30 unit s example 10 description 'Timeout of requests'"`
+		Password string `config:"password is_secret example 'p4ss' description 'Password of user'"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := r.Schema()
+	if schema[0].Description != "Timeout of requests" || schema[0].Unit != "s" || schema[0].Example != int64(10) {
+		t.Errorf("invalid info: %+v", schema[0])
+	}
+	if schema[1].Example != "******" {
+		t.Errorf("example of secret must be redacted: %v", schema[1].Example)
+	}
+	if template := schema[0].Template(); template != "uint default 30 unit s example 10 - Timeout of requests" {
+		t.Errorf("invalid template: %v", template)
+	}
+	if template := schema[1].Template(); template != "string secret example ****** - Password of user" {
+		t.Errorf("invalid template: %v", template)
+	}
+}
+
+func TestProviderSchema(t *testing.T) {
+	type Config struct {
+		Port    *uint16 `config:"port is_required has_default 8080 unit s - Port of server"`
+		Servers map[string]struct {
+			Hosts []string `config:"hosts"`
+		} `config:"servers"`
+		Started []time.Time `config:"started"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &providers.Schema{Kind: ObjectKind, Fields: map[string]*providers.Schema{
+		"port": {Kind: UintKind},
+		"servers": {Kind: MapKind, Elem: &providers.Schema{Kind: ObjectKind, Fields: map[string]*providers.Schema{
+			"hosts": {Kind: ArrayKind, Elem: &providers.Schema{Kind: StringKind}},
+		}}},
+		"started": {Kind: ArrayKind, Elem: &providers.Schema{Kind: DatetimeKind}},
+	}}
+	if schema := r.providerSchema(); !reflect.DeepEqual(schema, expected) {
+		t.Errorf("invalid schema of providers: %+v", schema)
+	}
+}
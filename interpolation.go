@@ -0,0 +1,305 @@
+package reflector
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// References to other fields e.g. ${server.host} or ${servers[0].name}, references with scheme are resolvers,
+// escaped $${name} is replaced by ${name} without interpolation
+var interpolationPattern = regexp.MustCompile(`(\$?)\$\{([^{}:$\s]+)\}`)
+
+// Interpolation of references between fields of effective data
+type interpolation struct {
+	loader    *valueLoader
+	fields    []reflectionField
+	data      map[string]interface{}
+	resolved  map[string]interface{} // interpolated values by path
+	resolving []string               // paths being interpolated for detection of cycles
+}
+
+// Decrypt encrypted values and replace references to other fields in string values
+// of data merged with defaults, problems are reported as validation errors of loader
+func (loader *valueLoader) interpolate(fields []reflectionField, data map[string]interface{}) map[string]interface{} {
+	data, _ = transformation{defaults: true}.fields(fields, data, "")
+	// values are decrypted with their own paths before they are referenced by other fields
+	data, _ = transformation{replace: loader.decryptValue}.fields(fields, data, "")
+	interpolation := &interpolation{
+		loader:   loader,
+		fields:   fields,
+		data:     data,
+		resolved: map[string]interface{}{},
+	}
+	data, _ = transformation{replace: interpolation.interpolateValue}.fields(fields, data, "")
+	return data
+}
+
+// Interpolate string value of field
+func (interpolation *interpolation) interpolateValue(field reflectionField, s string,
+	path string) (interface{}, bool) {
+	if !interpolationPattern.MatchString(s) || interpolation.loader.failed[path] {
+		return s, false
+	}
+	resolved, reference, err := interpolation.resolve(path, s)
+	if err != nil {
+		interpolation.loader.addFailure(&ValidationError{
+			Path:      path,
+			Expected:  field.fieldType.Kind(),
+			Received:  "string",
+			Code:      ReferenceCode,
+			Reference: reference,
+			Err:       err,
+			secret:    field.isSecret,
+		})
+		return s, false
+	}
+	return resolved, true
+}
+
+// Resolve references in string value of field with path, string which is a single reference
+// gets value of referenced field with its type, returns failed reference with error
+func (interpolation *interpolation) resolve(path string, s string) (interface{}, string, error) {
+	if value, ok := interpolation.resolved[path]; ok {
+		return value, "", nil
+	}
+	for index, resolving := range interpolation.resolving {
+		if resolving == path {
+			cycle := append(append([]string{}, interpolation.resolving[index:]...), path)
+			return nil, "", errors.New(fmt.Sprintf("cycle of references %s", strings.Join(cycle, " -> ")))
+		}
+	}
+	interpolation.resolving = append(interpolation.resolving, path)
+	defer func() {
+		interpolation.resolving = interpolation.resolving[:len(interpolation.resolving)-1]
+	}()
+
+	var result interface{}
+	if match := interpolationPattern.FindStringSubmatch(s); match[0] == s && match[1] == "" {
+		value, err := interpolation.value(match[2])
+		if err != nil {
+			return nil, s, err
+		}
+		result = value
+	} else {
+		var failed string
+		var resolveErr error
+		result = interpolationPattern.ReplaceAllStringFunc(s, func(reference string) string {
+			if resolveErr != nil {
+				return reference
+			}
+			match := interpolationPattern.FindStringSubmatch(reference)
+			if match[1] != "" {
+				return reference[1:]
+			}
+			value, err := interpolation.value(match[2])
+			if err != nil {
+				failed, resolveErr = reference, err
+				return reference
+			}
+			return fmt.Sprintf("%v", value)
+		})
+		if resolveErr != nil {
+			return nil, failed, resolveErr
+		}
+	}
+	interpolation.resolved[path] = result
+	return result, "", nil
+}
+
+// Get interpolated value of field by path
+func (interpolation *interpolation) value(path string) (interface{}, error) {
+	value, err := interpolation.lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := value.(string); ok && interpolationPattern.MatchString(s) {
+		value, _, err := interpolation.resolve(path, s)
+		return value, err
+	}
+	return value, nil
+}
+
+// Find value by path in field tree and data
+func (interpolation *interpolation) lookup(path string) (interface{}, error) {
+	_, value, err := lookupPath(interpolation.fields, interpolation.data, path)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := value.(string); ok && isEncrypted(s) {
+		return nil, errors.New(fmt.Sprintf("field `%s` can not be decrypted", path))
+	}
+	if value == nil {
+		return nil, errors.New(fmt.Sprintf("field `%s` does not have value", path))
+	}
+	return value, nil
+}
+
+// Find field and its value by path in field tree and data, value is nil when data does not have it
+func lookupPath(fields []reflectionField, data map[string]interface{}, path string) (*reflectionField,
+	interface{}, error) {
+	segments, ok := pathSegments(path)
+	if !ok {
+		return nil, nil, errors.New(fmt.Sprintf("invalid path `%s`", path))
+	}
+	notExists := errors.New(fmt.Sprintf("field `%s` does not exist", path))
+
+	var value interface{} = data
+	var field *reflectionField
+	for _, segment := range segments {
+		index, isIndex := segment.(int)
+		switch {
+		case field == nil || field.isStruct:
+			name, _ := segment.(string)
+			field = nil
+			for i := range fields {
+				if fields[i].configField.Name == name {
+					field = &fields[i]
+					break
+				}
+			}
+			if field == nil {
+				return nil, nil, notExists
+			}
+			fields = field.fields
+			data, _ := value.(map[string]interface{})
+			value = data[name]
+		case field.fieldType.Kind() == reflect.Slice && isIndex:
+			field = field.elem
+			if data, ok := toSlice(value); ok && index < len(data) {
+				value = data[index]
+			} else {
+				value = nil
+			}
+		case field.fieldType.Kind() == reflect.Map && !isIndex:
+			field = field.elem
+			data, _ := value.(map[string]interface{})
+			value = data[segment.(string)]
+		default:
+			return nil, nil, notExists
+		}
+	}
+	return field, value, nil
+}
+
+// Split path e.g. server.params[2].label into names and indexes
+func pathSegments(path string) ([]interface{}, bool) {
+	var segments []interface{}
+	for _, part := range strings.Split(path, ".") {
+		name := part
+		if i := strings.Index(part, "["); i >= 0 {
+			name = part[:i]
+		}
+		if name == "" {
+			return nil, false
+		}
+		segments = append(segments, name)
+		for rest := part[len(name):]; rest != ""; {
+			end := strings.Index(rest, "]")
+			if rest[0] != '[' || end < 0 {
+				return nil, false
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, false
+			}
+			segments = append(segments, index)
+			rest = rest[end+1:]
+		}
+	}
+	return segments, true
+}
+
+// Transformation of data which copies only changed objects and slices, data of provider is not changed
+type transformation struct {
+	// set default values of missing fields including fields of missing structs
+	defaults bool
+	// replace string values, returns true if value is changed
+	replace func(field reflectionField, s string, path string) (interface{}, bool)
+}
+
+// Transform values of struct fields, returns true if data is changed
+func (transformation transformation) fields(fields []reflectionField, data map[string]interface{},
+	path string) (map[string]interface{}, bool) {
+	var result map[string]interface{} // copy of data is created on first change
+	set := func(key string, value interface{}) {
+		if result == nil {
+			result = make(map[string]interface{}, len(data)+1)
+			for key, value := range data {
+				result[key] = value
+			}
+		}
+		result[key] = value
+	}
+	for _, field := range fields {
+		name := field.configField.Name
+		if value, ok := data[name]; ok {
+			if value, changed := transformation.value(field, value, fieldPath(path, name)); changed {
+				set(name, value)
+			}
+		} else if transformation.defaults && field.configField.DefaultValue != nil {
+			set(name, field.configField.DefaultValue)
+		} else if transformation.defaults && field.isStruct && !field.isPointer {
+			// missing struct gets defaults of its fields, the same as when it is loaded
+			if value, changed := transformation.fields(field.fields, map[string]interface{}{},
+				fieldPath(path, name)); changed {
+				set(name, value)
+			}
+		}
+	}
+	if result == nil {
+		return data, false
+	}
+	return result, true
+}
+
+// Transform string value or nested values of structs, maps and slices, returns true if value is changed
+func (transformation transformation) value(field reflectionField, value interface{},
+	path string) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		if transformation.replace != nil {
+			return transformation.replace(field, v, path)
+		}
+	case map[string]interface{}:
+		if field.isStruct {
+			return transformation.fields(field.fields, v, path)
+		}
+		if field.fieldType.Kind() == reflect.Map {
+			var result map[string]interface{}
+			for key, elem := range v {
+				if elem, changed := transformation.value(*field.elem, elem, fieldPath(path, key)); changed {
+					if result == nil {
+						result = make(map[string]interface{}, len(v))
+						for key, elem := range v {
+							result[key] = elem
+						}
+					}
+					result[key] = elem
+				}
+			}
+			if result != nil {
+				return result, true
+			}
+		}
+	case []interface{}:
+		if field.fieldType.Kind() == reflect.Slice {
+			var result []interface{}
+			for index, elem := range v {
+				if elem, changed := transformation.value(*field.elem, elem, indexPath(path, index)); changed {
+					if result == nil {
+						result = append([]interface{}{}, v...)
+					}
+					result[index] = elem
+				}
+			}
+			if result != nil {
+				return result, true
+			}
+		}
+	}
+	return value, false
+}
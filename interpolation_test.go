@@ -0,0 +1,181 @@
+package reflector
+
+import (
+	"testing"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+func TestInterpolation(t *testing.T) {
+	type Config struct {
+		Server struct {
+			Host string `config:"host has_default 'localhost'"`
+			Port uint16 `config:"port"`
+		} `config:"server"`
+		Address string            `config:"address"`
+		Port    *uint16           `config:"port"`
+		URL     string            `config:"url"`
+		Backups []string          `config:"backups"`
+		Labels  map[string]string `config:"labels"`
+		Env     string            `config:"env has_default '${labels.env}'"`
+	}
+	config := &Config{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider([]byte(`{"server":{"port":8080},
+		"address":"${server.host}:${server.port}","port":"${server.port}","url":"http://${address}/",
+		"backups":["${server.host}-1","${backups[0]}-copy"],"labels":{"env":"prod"}}`))
+	if _, err := r.SetValues(provider); err != nil {
+		t.Fatal(err)
+	}
+	if config.Address != "localhost:8080" || config.Port == nil || *config.Port != 8080 ||
+		config.URL != "http://localhost:8080/" || len(config.Backups) != 2 ||
+		config.Backups[1] != "localhost-1-copy" || config.Env != "prod" {
+		t.Errorf("invalid values: %+v", config)
+	}
+}
+
+func TestInterpolationOfNestedDefaults(t *testing.T) {
+	type Config struct {
+		Server struct {
+			Host string `config:"host has_default 'localhost'"`
+			Port uint16 `config:"port has_default 8080"`
+			TLS  struct {
+				Cert string `config:"cert has_default '/etc/tls/cert.pem'"`
+			} `config:"tls"`
+		} `config:"server"`
+		Backup *struct {
+			Host string `config:"host has_default 'backup'"`
+		} `config:"backup"`
+		URL  string `config:"url"`
+		Cert string `config:"cert"`
+	}
+	config := &Config{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider([]byte(`{"url":"http://${server.host}:${server.port}",
+		"cert":"${server.tls.cert}"}`))
+	if _, err := r.SetValues(provider); err != nil {
+		t.Fatal(err)
+	}
+	if config.URL != "http://localhost:8080" || config.Cert != "/etc/tls/cert.pem" || config.Backup != nil {
+		t.Errorf("invalid values: %+v", config)
+	}
+
+	// optional struct does not get defaults without value
+	provider = providers.NewJsonDataProvider([]byte(`{"url":"${backup.host}"}`))
+	if _, err := r.SetValues(provider); err == nil {
+		t.Error("there must be an error for reference to missing optional struct")
+	}
+}
+
+func TestInterpolationErrors(t *testing.T) {
+	type Config struct {
+		First  string `config:"first"`
+		Second string `config:"second"`
+		Third  string `config:"third"`
+		Fourth string `config:"fourth"`
+		Fifth  string `config:"fifth"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider([]byte(`{"first":"${second}","second":"x${first}",
+		"third":"${server.host}","fourth":"${fifth}"}`))
+	_, err = r.SetValues(provider)
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("invalid type of error: %T", err)
+	}
+	expected := []string{
+		"can not resolve reference `${second}` for field `first`: cycle of references first -> second -> first",
+		"can not resolve reference `${first}` for field `second`: cycle of references second -> first -> second",
+		"can not resolve reference `${server.host}` for field `third`: field `server.host` does not exist",
+		"can not resolve reference `${fifth}` for field `fourth`: field `fifth` does not have value",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("invalid number of errors: %s", errs)
+	}
+	for i, err := range errs {
+		if err.Code != ReferenceCode || err.Error() != expected[i] {
+			t.Errorf("invalid error %d: %s", i, err)
+		}
+	}
+}
+
+func TestPathSegments(t *testing.T) {
+	if segments, ok := pathSegments("server.params[2][0].label"); !ok || len(segments) != 5 ||
+		segments[0] != "server" || segments[2] != 2 || segments[3] != 0 || segments[4] != "label" {
+		t.Errorf("invalid segments: %v", segments)
+	}
+	for _, path := range []string{"server..host", "params[x]", "params[1", "[1]"} {
+		if _, ok := pathSegments(path); ok {
+			t.Errorf("path %s must be invalid", path)
+		}
+	}
+}
+
+func TestInterpolationEscape(t *testing.T) {
+	type Config struct {
+		Host    string `config:"host"`
+		Command string `config:"command"`
+		Script  string `config:"script"`
+		Copy    string `config:"copy"`
+	}
+	config := &Config{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider([]byte(`{"host":"localhost","command":"echo $${HOME} ${host}",
+		"script":"$${env:HOME}","copy":"${command}"}`))
+	if _, err := r.SetValues(provider); err != nil {
+		t.Fatal(err)
+	}
+	if config.Command != "echo ${HOME} localhost" || config.Script != "${env:HOME}" ||
+		config.Copy != "echo ${HOME} localhost" {
+		t.Errorf("invalid values: %+v", config)
+	}
+
+	// reference which is not a field is an error
+	_, err = r.SetValues(providers.NewJsonDataProvider([]byte(`{"command":"echo ${HOME}"}`)))
+	if err == nil || err.Error() != "can not resolve reference `${HOME}` for field `command`: field `HOME` does not exist" {
+		t.Errorf("invalid error: %v", err)
+	}
+}
+
+func TestSetValuesDoesNotChangeData(t *testing.T) {
+	type Config struct {
+		Host   string `config:"host has_default 'localhost'"`
+		URL    string `config:"url"`
+		Server struct {
+			Port int               `config:"port has_default 80"`
+			Tags []string          `config:"tags"`
+			Env  map[string]string `config:"env"`
+		} `config:"server"`
+	}
+	config := &Config{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := staticDataProvider{"url": "http://${host}:${server.port}", "server": map[string]interface{}{
+		"tags": []interface{}{"${host}"}, "env": map[string]interface{}{"host": "${host}"}}}
+	if _, err := r.SetValues(data); err != nil {
+		t.Fatal(err)
+	}
+	if config.URL != "http://localhost:80" || config.Server.Tags[0] != "localhost" ||
+		config.Server.Env["host"] != "localhost" {
+		t.Errorf("invalid values: %+v", config)
+	}
+	server := data["server"].(map[string]interface{})
+	if len(data) != 2 || data["url"] != "http://${host}:${server.port}" || len(server) != 2 ||
+		server["tags"].([]interface{})[0] != "${host}" || server["env"].(map[string]interface{})["host"] != "${host}" {
+		t.Errorf("data of provider must not be changed: %v", data)
+	}
+}
@@ -0,0 +1,210 @@
+package reflector
+
+import (
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Dialect of generated JSON Schema
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Get JSON Schema (draft 2020-12) of reflection source, result can be marshaled by encoding/json
+func (reflection *Reflector) JSONSchema() map[string]interface{} {
+	schema := objectJSONSchema(reflection.fields)
+	schema["$schema"] = jsonSchemaDialect
+	return schema
+}
+
+// Schema of object with properties for fields, conditional requirements are checked by if/then
+func objectJSONSchema(fields []reflectionField) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	conditions := []interface{}{}
+	for _, field := range fields {
+		name := field.configField.Name
+		properties[name] = fieldJSONSchema(field)
+		if field.configField.DefaultValue != nil {
+			continue
+		}
+		if dependsOn := field.configField.DependsOn.ConfigFieldName; dependsOn != "" {
+			conditions = append(conditions, map[string]interface{}{
+				"if":   conditionJSONSchema(fields[field.dependsOn], field.configField.DependsOn.Value),
+				"then": map[string]interface{}{"required": []string{name}},
+			})
+		} else if field.configField.IsRequired || (field.isStruct && !field.isPointer && hasRequiredFields(field.fields)) {
+			// missing objects are loaded as empty objects, so they are required with their required fields
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	if len(conditions) > 0 {
+		schema["allOf"] = conditions
+	}
+	return schema
+}
+
+// Schema of field value, optional pointers also accept null
+func fieldJSONSchema(field reflectionField) map[string]interface{} {
+	var schema map[string]interface{}
+	switch field.fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		schema = map[string]interface{}{"type": "integer"}
+		if bits := field.fieldType.Bits(); bits < 64 {
+			schema["minimum"] = int64(-1) << (bits - 1)
+			schema["maximum"] = int64(1)<<(bits-1) - 1
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		schema = map[string]interface{}{"type": "integer", "minimum": 0}
+		if bits := field.fieldType.Bits(); bits < 64 {
+			schema["maximum"] = uint64(1)<<bits - 1
+		}
+	case reflect.Float32, reflect.Float64:
+		schema = map[string]interface{}{"type": "number"}
+		if field.fieldType.Kind() == reflect.Float32 {
+			schema["minimum"] = -math.MaxFloat32
+			schema["maximum"] = math.MaxFloat32
+		}
+	case reflect.String:
+		schema = map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		schema = map[string]interface{}{"type": "boolean"}
+	case reflect.Struct:
+		if field.fieldType == timeType {
+			schema = map[string]interface{}{"type": "string", "format": "date-time"}
+		} else {
+			schema = objectJSONSchema(field.fields)
+		}
+	case reflect.Slice:
+		schema = map[string]interface{}{"type": "array", "items": fieldJSONSchema(field.elemField())}
+	case reflect.Map:
+		schema = map[string]interface{}{"type": "object", "additionalProperties": fieldJSONSchema(field.elemField())}
+	default:
+		schema = map[string]interface{}{}
+	}
+
+	if description := field.configField.Description; description != "" {
+		schema["description"] = description
+	}
+	if unit := field.configField.Unit; unit != "" {
+		// unit is not a keyword of JSON Schema, unknown keywords are annotations
+		schema["x-unit"] = unit
+	}
+	if field.isSecret {
+		schema["writeOnly"] = true
+	} else {
+		if v := field.configField.DefaultValue; v != nil {
+			schema["default"] = jsonSchemaValue(field, v)
+		}
+		if v := field.configField.Example; v != nil {
+			schema["examples"] = []interface{}{jsonSchemaValue(field, v)}
+		}
+	}
+	// null of required pointer is reported as missing value
+	if field.isPointer && !isRequired(field) {
+		if t, ok := schema["type"].(string); ok {
+			schema["type"] = []string{t, "null"}
+		}
+	}
+	return schema
+}
+
+// Schema which is valid when value of field meets condition of is_required_if,
+// without has_value any non zero value meets condition
+func conditionJSONSchema(field reflectionField, expected interface{}) map[string]interface{} {
+	name := field.configField.Name
+	// missing field meets condition when its default value meets it
+	var required []string
+	if !defaultMeetsCondition(field, expected) {
+		required = []string{name}
+	}
+	var property map[string]interface{}
+	if expected != nil {
+		property = map[string]interface{}{"const": jsonSchemaValue(field, expected)}
+	} else if zero, ok := zeroJSONSchemaValue(field); ok {
+		// nil pointers do not meet condition as zero values
+		property = map[string]interface{}{"not": map[string]interface{}{"enum": []interface{}{zero, nil}}}
+	} else {
+		property = map[string]interface{}{}
+	}
+	condition := map[string]interface{}{
+		"properties": map[string]interface{}{name: property},
+	}
+	if required != nil {
+		condition["required"] = required
+	}
+	return condition
+}
+
+// Check if default value of field meets condition of is_required_if
+func defaultMeetsCondition(field reflectionField, expected interface{}) bool {
+	value := field.configField.DefaultValue
+	if value == nil {
+		return false
+	}
+	if expected != nil {
+		return reflect.DeepEqual(jsonSchemaValue(field, value), jsonSchemaValue(field, expected))
+	}
+	zero, ok := zeroJSONSchemaValue(field)
+	return !ok || !reflect.DeepEqual(jsonSchemaValue(field, value), jsonSchemaValue(field, zero))
+}
+
+// Check if fields have required values when they are loaded from empty object
+func hasRequiredFields(fields []reflectionField) bool {
+	for _, field := range fields {
+		if field.configField.DefaultValue != nil || field.configField.DependsOn.ConfigFieldName != "" {
+			continue
+		}
+		if field.configField.IsRequired || (field.isStruct && !field.isPointer && hasRequiredFields(field.fields)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value of tag converted to numeric type of field when it is possible
+func jsonSchemaValue(field reflectionField, value interface{}) interface{} {
+	switch field.fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v, err := toInt64(value); err == nil {
+			return v
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v, err := toUint64(value); err == nil {
+			return v
+		}
+	case reflect.Float32, reflect.Float64:
+		if v, err := toFloat64(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
+
+// Zero value of scalar field
+func zeroJSONSchemaValue(field reflectionField) (interface{}, bool) {
+	switch field.fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return 0, true
+	case reflect.String:
+		return "", true
+	case reflect.Bool:
+		return false, true
+	}
+	return nil, false
+}
+
+// Check if field is required without condition, explicit null does not get default value
+func isRequired(field reflectionField) bool {
+	return field.configField.IsRequired && field.configField.DependsOn.ConfigFieldName == ""
+}
@@ -0,0 +1,149 @@
+package reflector
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+func TestJSONSchema(t *testing.T) {
+	type Config struct {
+		Name     string  `config:"name is_required"`
+		Port     uint16  `config:"port is_required has_default 8080"`
+		Ratio    float64 `config:"ratio has_default 1"`
+		Debug    *bool   `config:"debug"`
+		Level    int8    `config:"level"`
+		Password string  `config:"password is_secret has_default 'secret'"`
+		TLS      bool    `config:"tls"`
+		Cert     string  `config:"cert is_required_if tls has_value true"`
+		Key      string  `config:"key is_required_if cert"`
+		Servers  []struct {
+			Host string `config:"host is_required"`
+		} `config:"servers"`
+		Labels map[string]string `config:"labels"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(r.JSONSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema",` +
+		`"allOf":[` +
+		`{"if":{"properties":{"tls":{"const":true}},"required":["tls"]},"then":{"required":["cert"]}},` +
+		`{"if":{"properties":{"cert":{"not":{"enum":["",null]}}},"required":["cert"]},"then":{"required":["key"]}}],` +
+		`"properties":{` +
+		`"cert":{"type":"string"},` +
+		`"debug":{"type":["boolean","null"]},` +
+		`"key":{"type":"string"},` +
+		`"labels":{"additionalProperties":{"type":"string"},"type":"object"},` +
+		`"level":{"maximum":127,"minimum":-128,"type":"integer"},` +
+		`"name":{"type":"string"},` +
+		`"password":{"type":"string","writeOnly":true},` +
+		`"port":{"default":8080,"maximum":65535,"minimum":0,"type":"integer"},` +
+		`"ratio":{"default":1,"type":"number"},` +
+		`"servers":{"items":{"properties":{"host":{"type":"string"}},"required":["host"],"type":"object"},` +
+		`"type":"array"},` +
+		`"tls":{"type":"boolean"}},` +
+		`"required":["name"],"type":"object"}`
+	if string(data) != expected {
+		t.Errorf("invalid schema: %s", data)
+	}
+}
+
+func TestJSONSchemaDocumentation(t *testing.T) {
+	type Config struct {
+		Timeout  uint   `config:"timeout unit s example 10 description 'Timeout of requests'"`
+		Password string `config:"password is_secret example 'p4ss'"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(r.JSONSchema()["properties"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"password":{"type":"string","writeOnly":true},` +
+		`"timeout":{"description":"Timeout of requests","examples":[10],"minimum":0,"type":"integer","x-unit":"s"}}`
+	if string(data) != expected {
+		t.Errorf("invalid schema: %s", data)
+	}
+}
+
+func TestJSONSchemaRequiredPointer(t *testing.T) {
+	type Config struct {
+		Port   *int `config:"port is_required"`
+		Backup *int `config:"backup"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties, _ := json.Marshal(r.JSONSchema()["properties"])
+	if string(properties) != `{"backup":{"type":["integer","null"]},"port":{"type":"integer"}}` {
+		t.Errorf("null must be valid only for optional pointer: %s", properties)
+	}
+}
+
+func TestJSONSchemaAgreesWithSetValues(t *testing.T) {
+	type Config struct {
+		Mode   string `config:"mode has_default 'tls'"`
+		Cert   string `config:"cert is_required_if mode has_value 'tls'"`
+		Port   int    `config:"port has_default 8080"`
+		Key    string `config:"key is_required_if port"`
+		Server struct {
+			Host     string `config:"host is_required"`
+			Database struct {
+				Name string `config:"name is_required"`
+			} `config:"database"`
+		} `config:"server"`
+		Backup *struct {
+			Host string `config:"host is_required"`
+		} `config:"backup"`
+		Labels struct {
+			Env string `config:"env"`
+		} `config:"labels"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// errors of empty object
+	_, err = r.SetValues(providers.NewJsonDataProvider([]byte(`{}`)))
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("invalid type of error: %T", err)
+	}
+	paths := []string{}
+	for _, err := range errs {
+		paths = append(paths, err.Path)
+	}
+	sort.Strings(paths)
+	if strings.Join(paths, ",") != "cert,key,server.database.name,server.host" {
+		t.Errorf("invalid errors: %s", err)
+	}
+
+	// schema requires the same fields for empty object
+	schema := r.JSONSchema()
+	data, err := json.Marshal(map[string]interface{}{"required": schema["required"], "allOf": schema["allOf"]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"allOf":[{"if":{"properties":{"mode":{"const":"tls"}}},"then":{"required":["cert"]}},` +
+		`{"if":{"properties":{"port":{"not":{"enum":[0,null]}}}},"then":{"required":["key"]}}],` +
+		`"required":["server"]}`
+	if string(data) != expected {
+		t.Errorf("invalid schema: %s", data)
+	}
+	server := schema["properties"].(map[string]interface{})["server"].(map[string]interface{})
+	if data, _ := json.Marshal(server["required"]); string(data) != `["database","host"]` {
+		t.Errorf("invalid required fields of server: %s", data)
+	}
+}
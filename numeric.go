@@ -0,0 +1,116 @@
+package reflector
+
+import (
+	"errors"
+	"math"
+	"reflect"
+)
+
+var (
+	errNumericType       = errors.New("value is not a number")
+	errNumericOverflow   = errors.New("value overflows")
+	errNumericTruncation = errors.New("value is truncated")
+)
+
+// Convert numeric value to int64 without loss
+func toInt64(data interface{}) (int64, error) {
+	switch v := reflect.ValueOf(data); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return 0, errNumericOverflow
+		}
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.Trunc(f) != f {
+			return 0, errNumericTruncation
+		}
+		if f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, errNumericOverflow
+		}
+		return int64(f), nil
+	}
+	return 0, errNumericType
+}
+
+// Convert numeric value to uint64 without loss
+func toUint64(data interface{}) (uint64, error) {
+	switch v := reflect.ValueOf(data); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return 0, errNumericOverflow
+		}
+		return uint64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.Trunc(f) != f {
+			return 0, errNumericTruncation
+		}
+		if f < 0 || f >= math.MaxUint64 {
+			return 0, errNumericOverflow
+		}
+		return uint64(f), nil
+	}
+	return 0, errNumericType
+}
+
+// Convert numeric value to float64 without loss
+func toFloat64(data interface{}) (float64, error) {
+	switch v := reflect.ValueOf(data); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f := float64(v.Int())
+		if f >= math.MaxInt64 || int64(f) != v.Int() {
+			return 0, errNumericTruncation
+		}
+		return f, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f := float64(v.Uint())
+		if f >= math.MaxUint64 || uint64(f) != v.Uint() {
+			return 0, errNumericTruncation
+		}
+		return f, nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	}
+	return 0, errNumericType
+}
+
+// Set numeric value with coercion between numeric types
+func setNumericValue(value *reflect.Value, data interface{}) error {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := toInt64(data)
+		if err != nil {
+			return err
+		}
+		if value.OverflowInt(v) {
+			return errNumericOverflow
+		}
+		value.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := toUint64(data)
+		if err != nil {
+			return err
+		}
+		if value.OverflowUint(v) {
+			return errNumericOverflow
+		}
+		value.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := toFloat64(data)
+		if err != nil {
+			return err
+		}
+		if value.OverflowFloat(v) {
+			return errNumericOverflow
+		}
+		value.SetFloat(v)
+	default:
+		return errNumericType
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package reflector
+
+import (
+	"math"
+	"testing"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+func TestNumericCoercion(t *testing.T) {
+	type Config struct {
+		Int     int     `config:"int"`
+		Int32   int32   `config:"int_small"`
+		Int64   int64   `config:"int_big"`
+		Uint8   uint8   `config:"uint_small"`
+		Uint64  uint64  `config:"uint_big has_default 10"`
+		Float32 float32 `config:"float_small"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider([]byte(`{"int":4.0,"int_small":-12,"int_big":1024,"uint_small":255,"float_small":1.5}`))
+	if config, err := r.SetValues(provider); err != nil {
+		t.Error(err)
+	} else {
+		config := config.(*Config)
+		if config.Int != 4 || config.Int32 != -12 || config.Int64 != 1024 {
+			t.Errorf("invalid integer values: %+v", config)
+		}
+		if config.Uint8 != 255 || config.Uint64 != 10 {
+			t.Errorf("invalid unsigned values: %+v", config)
+		}
+		if config.Float32 != 1.5 {
+			t.Errorf("invalid float value: %v", config.Float32)
+		}
+	}
+}
+
+func TestNumericErrors(t *testing.T) {
+	type Config struct {
+		Int   int   `config:"int"`
+		Int8  int8  `config:"int_tiny"`
+		Uint8 uint8 `config:"uint_small"`
+	}
+	tests := []string{
+		`{"int":4.5}`,
+		`{"int_tiny":128}`,
+		`{"uint_small":-1}`,
+		`{"uint_small":256}`,
+		`{"int":"10"}`,
+		`{"int":1e20}`,
+	}
+	for _, test := range tests {
+		r, err := New(&Config{}, "config")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.SetValues(providers.NewJsonDataProvider([]byte(test))); err == nil {
+			t.Errorf("there must be an error for %s", test)
+		}
+	}
+}
+
+func TestNumericConversion(t *testing.T) {
+	if v, err := toInt64(uint16(12)); err != nil || v != 12 {
+		t.Errorf("invalid conversion: %v, %s", v, err)
+	}
+	if _, err := toInt64(uint64(math.MaxUint64)); err != errNumericOverflow {
+		t.Error("there must be an overflow")
+	}
+	if _, err := toUint64(-1.0); err != errNumericOverflow {
+		t.Error("there must be an overflow")
+	}
+	if _, err := toFloat64(int64(math.MaxInt64)); err != errNumericTruncation {
+		t.Error("there must be a truncation")
+	}
+	if _, err := toFloat64("1"); err != errNumericType {
+		t.Error("string is not a number")
+	}
+}
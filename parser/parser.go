@@ -24,7 +24,11 @@ type Parser struct {
 type ConfigField struct {
 	Name         string
 	IsRequired   bool
+	IsSecret     bool
 	DefaultValue TokenValue
+	Description  string
+	Example      TokenValue
+	Unit         string
 	DependsOn    struct {
 			     ConfigFieldName string
 			     Value           TokenValue
@@ -57,6 +61,15 @@ func (parser *Parser) Parse() (*ConfigField, error) {
 
 	if token == identValueToken {
 		configField.Name = value.(string)
+	} else if isDocumentationToken(token) {
+		// documentation keyword without value is a name of field
+		next, _ := parser.scanIgnoreWhitespaces()
+		parser.unscan()
+		if !isValueToken(next) && next != identValueToken {
+			configField.Name = value.(string)
+		} else if err := parser.parseDocumentation(configField, token); err != nil {
+			return nil, err
+		}
 	} else {
 		parser.unscan()
 	}
@@ -72,6 +85,18 @@ func (parser *Parser) Parse() (*ConfigField, error) {
 			configField.IsRequired = true
 		}
 
+		// processing is_secret
+		if token == isSecretToken {
+			configField.IsSecret = true
+		}
+
+		// processing description, example and unit
+		if isDocumentationToken(token) {
+			if err := parser.parseDocumentation(configField, token); err != nil {
+				return nil, err
+			}
+		}
+
 		// processing has_default
 		if token == hasDefaultToken {
 			// scan for value
@@ -87,7 +112,7 @@ func (parser *Parser) Parse() (*ConfigField, error) {
 		if token == isRequiredIfToken {
 			// scan for ident
 			token, value = parser.scanIgnoreWhitespaces()
-			if token != identValueToken {
+			if token != identValueToken && !isDocumentationToken(token) {
 				return nil, errors.New("if required name of config field")
 			} else {
 				configField.DependsOn.ConfigFieldName = value.(string)
@@ -109,6 +134,29 @@ func (parser *Parser) Parse() (*ConfigField, error) {
 	return configField, nil
 }
 
+// Parse value of description, example or unit
+func (parser *Parser) parseDocumentation(configField *ConfigField, keyword Token) error {
+	token, value := parser.scanIgnoreWhitespaces()
+	switch keyword {
+	case descriptionToken:
+		if token != stringValueToken {
+			return errors.New("description needs string value")
+		}
+		configField.Description = value.(string)
+	case exampleToken:
+		if !isValueToken(token) {
+			return errors.New("example needs value: string, int, float, bool, slice")
+		}
+		configField.Example = value
+	case unitToken:
+		if token != stringValueToken && token != identValueToken {
+			return errors.New("unit needs string value")
+		}
+		configField.Unit = value.(string)
+	}
+	return nil
+}
+
 // Scan ignore white spaces
 func (parser *Parser) scanIgnoreWhitespaces() (token Token, value TokenValue) {
 	token, value = parser.scan()
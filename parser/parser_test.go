@@ -80,4 +80,69 @@ func TestIfStatementError(t *testing.T) {
 	}
 }
 
+func TestIsSecret(t *testing.T) {
+	parser := parser.NewParser("password is_required is_secret")
+	if configField, err := parser.Parse(); err != nil {
+		t.Errorf("there can not be an error: %s", err)
+	} else {
+		if !configField.IsSecret {
+			t.Error("field is secret")
+		}
+		if !configField.IsRequired {
+			t.Error("field is required")
+		}
+	}
+}
 
+func TestDocumentation(t *testing.T) {
+	parser := parser.NewParser("timeout has_default 30 description 'Timeout of requests' example 10 unit s")
+	if configField, err := parser.Parse(); err != nil {
+		t.Errorf("there can not be an error: %s", err)
+	} else {
+		if configField.Name != "timeout" {
+			t.Errorf("invalid name: %s", configField.Name)
+		}
+		if configField.Description != "Timeout of requests" {
+			t.Errorf("invalid description: %s", configField.Description)
+		}
+		if configField.Example != int64(10) {
+			t.Errorf("invalid example: %v", configField.Example)
+		}
+		if configField.Unit != "s" {
+			t.Errorf("invalid unit: %s", configField.Unit)
+		}
+	}
+}
+
+func TestDocumentationKeywordsAsNames(t *testing.T) {
+	tests := map[string]string{
+		"description is_required":      "description",
+		"unit":                         "unit",
+		"example example 'x'":          "example",
+		"description 'Name of server'": "",
+	}
+	for input, name := range tests {
+		parser := parser.NewParser(input)
+		if configField, err := parser.Parse(); err != nil {
+			t.Errorf("there can not be an error for %s: %s", input, err)
+		} else if configField.Name != name {
+			t.Errorf("invalid name for %s: %s", input, configField.Name)
+		}
+	}
+
+	parser := parser.NewParser("tls is_required_if unit")
+	if configField, err := parser.Parse(); err != nil {
+		t.Errorf("there can not be an error: %s", err)
+	} else if configField.DependsOn.ConfigFieldName != "unit" {
+		t.Errorf("invalid depends on: %s", configField.DependsOn.ConfigFieldName)
+	}
+}
+
+func TestDocumentationError(t *testing.T) {
+	for _, input := range []string{"name description", "name description 10", "name example", "name unit 10"} {
+		parser := parser.NewParser(input)
+		if _, err := parser.Parse(); err == nil {
+			t.Errorf("there must be an error for %s", input)
+		}
+	}
+}
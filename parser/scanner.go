@@ -115,6 +115,14 @@ func (scanner *Scanner) scanIdent() (Token, TokenValue) {
 		return hasDefaultToken, nil
 	case "is_required_if":
 		return isRequiredIfToken, nil
+	case "is_secret":
+		return isSecretToken, nil
+	case "description":
+		return descriptionToken, buffer.String()
+	case "example":
+		return exampleToken, buffer.String()
+	case "unit":
+		return unitToken, buffer.String()
 	case "true":
 		return booleanValueToken, true
 	case "false":
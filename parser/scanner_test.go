@@ -143,6 +143,22 @@ func TestIdents(t *testing.T) {
 			input: "is_required_if",
 			token: isRequiredIfToken,
 		},
+		{
+			input: "is_secret",
+			token: isSecretToken,
+		},
+		{
+			input: "description",
+			token: descriptionToken,
+		},
+		{
+			input: "example",
+			token: exampleToken,
+		},
+		{
+			input: "unit",
+			token: unitToken,
+		},
 		{
 			input: "some_value",
 			token: identValueToken,
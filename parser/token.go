@@ -18,6 +18,10 @@ const (
 	isRequiredIfToken // is_required
 	hasDefaultToken // has_default
 	hasValueToken // has_value
+	isSecretToken // is_secret
+	descriptionToken // description
+	exampleToken // example
+	unitToken // unit
 
 )
 
@@ -34,6 +38,10 @@ var names = map[Token]string{
 	isRequiredIfToken: "is_required_if",
 	hasDefaultToken: "has_default ...",
 	hasValueToken: "has_value ...",
+	isSecretToken: "is_secret",
+	descriptionToken: "description ...",
+	exampleToken: "example ...",
+	unitToken: "unit ...",
 }
 
 func (token Token) String() string {
@@ -44,6 +52,16 @@ func (token Token) String() string {
 	}
 }
 
+// Documentation keywords can be used as names of fields
+func isDocumentationToken(token Token) bool {
+	return token == descriptionToken || token == exampleToken || token == unitToken
+}
+
+func isValueToken(token Token) bool {
+	return token == stringValueToken || token == numberValueToken || token == floatValueToken ||
+		token == sliceValueToken || token == booleanValueToken
+}
+
 func isWhiteSpace(ch rune) bool {
 	return ch == ' '
 }
@@ -0,0 +1,14 @@
+package providers
+
+// Data provider interface, same as reflector.DataProvider
+type DataProvider interface {
+	Load() (map[string]interface{}, error)
+	Unload(map[string]interface{}) error
+	Data() interface{}
+}
+
+// Data provider which needs schema of reflector for loading, same as reflector.SchemaDataProvider
+type SchemaDataProvider interface {
+	DataProvider
+	SetSchema(schema *Schema)
+}
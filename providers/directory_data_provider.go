@@ -0,0 +1,166 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Provider of directory with file per key e.g. Kubernetes ConfigMap or Secret volume,
+// file names are config names and subdirectories are nested objects,
+// values are parsed according to schema of reflector
+type DirectoryDataProvider struct {
+	path   string
+	schema *Schema
+	files  map[string][]byte // content of files by paths relative to directory
+}
+
+func NewDirectoryDataProvider(path string) *DirectoryDataProvider {
+	return &DirectoryDataProvider{
+		path: path,
+	}
+}
+
+// Set schema of reflector
+func (provider *DirectoryDataProvider) SetSchema(schema *Schema) {
+	provider.schema = schema
+}
+
+func (provider *DirectoryDataProvider) Load() (map[string]interface{}, error) {
+	tree, err := readDirectory(provider.path)
+	if err != nil {
+		return nil, err
+	}
+	provider.files = map[string][]byte{}
+	if err := treeFiles(provider.files, "", tree); err != nil {
+		return nil, errors.New(fmt.Sprintf("%s: %s", provider.path, err))
+	}
+	result, err := typedTree(tree, provider.schema, "")
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("%s: %s", provider.path, err))
+	}
+	return result.(map[string]interface{}), nil
+}
+
+// Unload data as file per key, slices of scalars are separated by comma,
+// directory is not changed until Save
+func (provider *DirectoryDataProvider) Unload(data map[string]interface{}) error {
+	files := map[string][]byte{}
+	if err := treeFiles(files, "", data); err != nil {
+		return errors.New(fmt.Sprintf("%s: %s", provider.path, err))
+	}
+	provider.files = files
+	return nil
+}
+
+// Content of files by paths relative to directory
+func (provider *DirectoryDataProvider) Data() interface{} {
+	return provider.files
+}
+
+// Write unloaded files to directory
+func (provider *DirectoryDataProvider) Save() error {
+	if provider.files == nil {
+		return errors.New(fmt.Sprintf("%s: there is no data to save", provider.path))
+	}
+	for _, name := range sortedFileNames(provider.files) {
+		path := filepath.Join(provider.path, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, provider.files[name], 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read directory tree, entries which start with `..` (e.g. ..data of Kubernetes volumes) are skipped
+// and symbolic links are followed
+func readDirectory(path string) (map[string]interface{}, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	tree := map[string]interface{}{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, "..") {
+			continue
+		}
+		entryPath := filepath.Join(path, name)
+		info, err := os.Stat(entryPath)
+		if err != nil {
+			return nil, err
+		}
+		if info.IsDir() {
+			if tree[name], err = readDirectory(entryPath); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		content, err := os.ReadFile(entryPath)
+		if err != nil {
+			return nil, err
+		}
+		tree[name] = strings.TrimRight(string(content), "\r\n")
+	}
+	return tree, nil
+}
+
+// Collect content of file per key, keys must be valid names of files
+func treeFiles(files map[string][]byte, path string, data map[string]interface{}) error {
+	for key, value := range data {
+		if !isFileName(key) {
+			return errors.New(fmt.Sprintf("invalid name of file `%s`", key))
+		}
+		entryPath := filepath.Join(path, key)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if err := treeFiles(files, entryPath, v); err != nil {
+				return err
+			}
+			continue
+		case []interface{}:
+			if len(v) > 0 {
+				if _, ok := v[0].(map[string]interface{}); ok {
+					// slice of objects uses index as name of directory
+					for index, item := range v {
+						object, _ := item.(map[string]interface{})
+						if err := treeFiles(files, filepath.Join(entryPath, strconv.Itoa(index)), object); err != nil {
+							return err
+						}
+					}
+					continue
+				}
+			}
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprintf("%v", item))
+			}
+			value = strings.Join(items, ",")
+		}
+		files[entryPath] = []byte(fmt.Sprintf("%v\n", value))
+	}
+	return nil
+}
+
+// Check if key can be used as name of file in directory
+func isFileName(key string) bool {
+	return key != "" && key != "." && !strings.HasPrefix(key, "..") &&
+		!strings.ContainsAny(key, `/\`) && !strings.ContainsRune(key, os.PathSeparator)
+}
+
+// Names of files in stable order
+func sortedFileNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,130 @@
+package providers
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Create files of directory, names are relative paths
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDirectoryLoad(t *testing.T) {
+	dir := t.TempDir()
+	// layout of Kubernetes volume
+	writeFiles(t, dir, map[string]string{
+		"..2024_01_01/host":                   "localhost\n",
+		"..2024_01_01/port":                   "8081\r\n",
+		"..2024_01_01/tags":                   "a,b",
+		"..2024_01_01/server/name":            "main\n\n",
+		"..2024_01_01/server/params/0/label":  "first",
+		"..2024_01_01/server/params/1/weight": "0.5",
+	})
+	links := map[string]string{
+		"..data": "..2024_01_01",
+		"host":   "..data/host",
+		"port":   "..data/port",
+		"tags":   "..data/tags",
+		"server": "..data/server",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	provider := NewDirectoryDataProvider(dir)
+	provider.SetSchema(iniSchema)
+	result, err := provider.Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"host": "localhost",
+		"port": int64(8081),
+		"tags": []interface{}{"a", "b"},
+		"server": map[string]interface{}{
+			"name": "main",
+			"params": []interface{}{
+				map[string]interface{}{"label": "first"},
+				map[string]interface{}{"weight": 0.5},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, result)
+	}
+	if files := provider.Data().(map[string][]byte); string(files[filepath.Join("server", "name")]) != "main\n" {
+		t.Errorf("unexpected files: %q", files)
+	}
+}
+
+func TestDirectoryErrors(t *testing.T) {
+	if _, err := NewDirectoryDataProvider(filepath.Join(t.TempDir(), "missing")).Load(); err == nil {
+		t.Error("there must be an error for missing directory")
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"port": "80s"})
+	provider := NewDirectoryDataProvider(dir)
+	provider.SetSchema(iniSchema)
+	if _, err := provider.Load(); err == nil {
+		t.Error("there must be an error for invalid value")
+	}
+}
+
+func TestDirectoryUnload(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "config")
+	data := map[string]interface{}{
+		"host": "localhost",
+		"port": int64(8080),
+		"tags": []interface{}{"a", "b"},
+		"server": map[string]interface{}{
+			"params": []interface{}{map[string]interface{}{"label": "first"}},
+		},
+	}
+	provider := NewDirectoryDataProvider(dir)
+	if err := provider.Unload(data); err != nil {
+		t.Fatal(err)
+	}
+	files := provider.Data().(map[string][]byte)
+	if string(files["port"]) != "8080\n" || string(files[filepath.Join("server", "params", "0", "label")]) != "first\n" {
+		t.Errorf("unexpected files: %q", files)
+	}
+	// unload does not write directory
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("directory must not be written by unload: %v", err)
+	}
+	if err := provider.Save(); err != nil {
+		t.Fatal(err)
+	}
+	provider.SetSchema(iniSchema)
+	if result, err := provider.Load(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(result, data) {
+		t.Errorf("expected: %v, actual: %v", data, result)
+	}
+}
+
+func TestDirectoryUnloadInvalidKeys(t *testing.T) {
+	for _, key := range []string{"../../x", "a/b", "..data", ".", ""} {
+		provider := NewDirectoryDataProvider(t.TempDir())
+		data := map[string]interface{}{"labels": map[string]interface{}{key: "value"}}
+		if err := provider.Unload(data); err == nil {
+			t.Errorf("there must be an error for key `%s`", key)
+		}
+	}
+	if err := NewDirectoryDataProvider(t.TempDir()).Save(); err == nil {
+		t.Error("there must be an error without data")
+	}
+}
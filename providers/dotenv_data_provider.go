@@ -0,0 +1,296 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Provider of .env files, names of variables are mapped to fields the same way as by EnvDataProvider
+type DotenvDataProvider struct {
+	data   []byte
+	prefix string
+	schema *Schema
+}
+
+func NewDotenvDataProvider(data []byte, prefix string) *DotenvDataProvider {
+	return &DotenvDataProvider{
+		data:   data,
+		prefix: prefix,
+	}
+}
+
+// Set schema of reflector
+func (provider *DotenvDataProvider) SetSchema(schema *Schema) {
+	provider.schema = schema
+}
+
+func (provider *DotenvDataProvider) Load() (map[string]interface{}, error) {
+	if provider.schema == nil {
+		return nil, errors.New("schema is required to load dotenv variables")
+	}
+	variables, err := parseDotenv(string(provider.data))
+	if err != nil {
+		return nil, err
+	}
+	return loadVariables(variables, provider.prefix, provider.schema.Fields)
+}
+
+// Unload documentation of recognised variables
+func (provider *DotenvDataProvider) Unload(data map[string]interface{}) error {
+	provider.data = documentVariables(provider.prefix, data)
+	return nil
+}
+
+func (provider *DotenvDataProvider) Data() interface{} {
+	return provider.data
+}
+
+// Parser of dotenv syntax
+type dotenvParser struct {
+	s         string
+	position  int
+	line      int
+	variables map[string]string
+}
+
+// Parse dotenv content:
+//   - optional `export` prefix
+//   - single quoted values are literal and can be multi-line
+//   - double quoted values support escapes, expansion and can be multi-line
+//   - unquoted values are trimmed, support expansion and inline comments after white space
+//   - ${VAR} and $VAR are expanded with earlier entries
+func parseDotenv(s string) (map[string]string, error) {
+	parser := &dotenvParser{s: s, line: 1, variables: map[string]string{}}
+	for {
+		parser.skipSpaces(true)
+		if parser.eof() {
+			return parser.variables, nil
+		}
+		if parser.peek() == '#' {
+			parser.skipLine()
+			continue
+		}
+		if err := parser.parseEntry(); err != nil {
+			return nil, errors.New(fmt.Sprintf("line %d: %s", parser.line, err))
+		}
+	}
+}
+
+func (parser *dotenvParser) eof() bool {
+	return parser.position >= len(parser.s)
+}
+
+func (parser *dotenvParser) peek() byte {
+	return parser.s[parser.position]
+}
+
+func (parser *dotenvParser) next() byte {
+	ch := parser.s[parser.position]
+	parser.position++
+	if ch == '\n' {
+		parser.line++
+	}
+	return ch
+}
+
+// Skip spaces and optionally new lines
+func (parser *dotenvParser) skipSpaces(newLines bool) {
+	for !parser.eof() {
+		switch parser.peek() {
+		case ' ', '\t', '\r':
+		case '\n':
+			if !newLines {
+				return
+			}
+		default:
+			return
+		}
+		parser.next()
+	}
+}
+
+func (parser *dotenvParser) skipLine() {
+	for !parser.eof() && parser.next() != '\n' {
+	}
+}
+
+func (parser *dotenvParser) parseEntry() error {
+	key := parser.parseKey()
+	if key == "export" && !parser.eof() && (parser.peek() == ' ' || parser.peek() == '\t') {
+		parser.skipSpaces(false)
+		key = parser.parseKey()
+	}
+	if key == "" {
+		return errors.New("variable name is expected")
+	}
+	parser.skipSpaces(false)
+	if parser.eof() || parser.peek() != '=' {
+		return errors.New(fmt.Sprintf("`=` is expected after %s", key))
+	}
+	parser.next()
+	parser.skipSpaces(false)
+
+	var value string
+	var err error
+	if parser.eof() {
+		value = ""
+	} else if parser.peek() == '\'' {
+		value, err = parser.parseSingleQuoted()
+	} else if parser.peek() == '"' {
+		value, err = parser.parseDoubleQuoted()
+	} else {
+		value = parser.parseUnquoted()
+	}
+	if err != nil {
+		return err
+	}
+	parser.variables[key] = value
+	return nil
+}
+
+func (parser *dotenvParser) parseKey() string {
+	start := parser.position
+	for !parser.eof() {
+		ch := parser.peek()
+		if !isLetterOrDigit(ch) && ch != '_' && ch != '.' {
+			break
+		}
+		parser.next()
+	}
+	return parser.s[start:parser.position]
+}
+
+func (parser *dotenvParser) parseSingleQuoted() (string, error) {
+	parser.next() // skip quote
+	start := parser.position
+	for !parser.eof() {
+		if parser.next() == '\'' {
+			value := parser.s[start : parser.position-1]
+			return value, parser.endOfValue()
+		}
+	}
+	return "", errors.New("unterminated single quoted value")
+}
+
+func (parser *dotenvParser) parseDoubleQuoted() (string, error) {
+	parser.next() // skip quote
+	var buffer strings.Builder
+	for !parser.eof() {
+		ch := parser.next()
+		switch ch {
+		case '"':
+			return buffer.String(), parser.endOfValue()
+		case '\\':
+			if parser.eof() {
+				break
+			}
+			switch escaped := parser.next(); escaped {
+			case 'n':
+				buffer.WriteByte('\n')
+			case 't':
+				buffer.WriteByte('\t')
+			case 'r':
+				buffer.WriteByte('\r')
+			case '"', '\\', '$':
+				buffer.WriteByte(escaped)
+			default:
+				buffer.WriteByte('\\')
+				buffer.WriteByte(escaped)
+			}
+		case '$':
+			buffer.WriteString(parser.parseExpansion())
+		default:
+			buffer.WriteByte(ch)
+		}
+	}
+	return "", errors.New("unterminated double quoted value")
+}
+
+func (parser *dotenvParser) parseUnquoted() string {
+	var buffer strings.Builder
+	for !parser.eof() {
+		ch := parser.peek()
+		if ch == '\n' {
+			break
+		}
+		if ch == '#' && buffer.Len() > 0 {
+			// inline comment must follow white space
+			if last := buffer.String()[buffer.Len()-1]; last == ' ' || last == '\t' {
+				parser.skipLine()
+				break
+			}
+		}
+		parser.next()
+		if ch == '$' {
+			buffer.WriteString(parser.parseExpansion())
+		} else {
+			buffer.WriteByte(ch)
+		}
+	}
+	return strings.TrimSpace(buffer.String())
+}
+
+// Parse ${VAR} or $VAR after dollar sign and return value of earlier entry,
+// braces with other content and escaped $${...} are kept as written
+func (parser *dotenvParser) parseExpansion() string {
+	if parser.eof() {
+		return "$"
+	}
+	if strings.HasPrefix(parser.s[parser.position:], "${") {
+		// escaped $${...} is kept for reflector
+		parser.next()
+		return "$$"
+	}
+	if parser.peek() == '{' {
+		end := strings.IndexByte(parser.s[parser.position:], '}')
+		if end < 0 {
+			return "$"
+		}
+		name := parser.s[parser.position+1 : parser.position+end]
+		if !isVariableName(name) {
+			// references like ${env:X} or ${server.host} are resolved by reflector
+			return "$"
+		}
+		parser.position += end + 1
+		return parser.variables[name]
+	}
+	start := parser.position
+	for !parser.eof() && (isLetterOrDigit(parser.peek()) || parser.peek() == '_') {
+		parser.next()
+	}
+	if start == parser.position {
+		return "$"
+	}
+	return parser.variables[parser.s[start:parser.position]]
+}
+
+// Only spaces and comment are allowed after quoted value
+func (parser *dotenvParser) endOfValue() error {
+	parser.skipSpaces(false)
+	if parser.eof() || parser.peek() == '\n' {
+		return nil
+	}
+	if parser.peek() == '#' {
+		parser.skipLine()
+		return nil
+	}
+	return errors.New(fmt.Sprintf("unexpected `%c` after quoted value", parser.peek()))
+}
+
+// Check if name is a valid name of variable e.g. APP_HOST
+func isVariableName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for index := 0; index < len(name); index++ {
+		if !isLetterOrDigit(name[index]) && name[index] != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetterOrDigit(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
+}
@@ -0,0 +1,100 @@
+package providers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	raw := `
+# comment
+APP_HOST=localhost
+export APP_PORT = 8080
+APP_URL=http://${APP_HOST}:$APP_PORT/path # inline comment
+APP_HASH=value#not_comment
+APP_LITERAL='${APP_HOST}\n'
+APP_ESCAPED="tab\tquote\"dollar\$APP_HOST"
+APP_EXPANDED="${APP_HOST}-${UNKNOWN}"
+APP_MULTILINE="first
+second"
+APP_SINGLE='first
+second' # comment
+APP_EMPTY=
+APP_PASSWORD=${env:PASSWORD}
+APP_ADDRESS="${server.host}:${APP_PORT}"
+APP_COMMAND=echo $${APP_HOST}
+`
+	variables, err := parseDotenv(raw)
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	expected := map[string]string{
+		"APP_HOST":      "localhost",
+		"APP_PORT":      "8080",
+		"APP_URL":       "http://localhost:8080/path",
+		"APP_HASH":      "value#not_comment",
+		"APP_LITERAL":   `${APP_HOST}\n`,
+		"APP_ESCAPED":   "tab\tquote\"dollar$APP_HOST",
+		"APP_EXPANDED":  "localhost-",
+		"APP_MULTILINE": "first\nsecond",
+		"APP_SINGLE":    "first\nsecond",
+		"APP_EMPTY":     "",
+		"APP_PASSWORD":  "${env:PASSWORD}",
+		"APP_ADDRESS":   "${server.host}:8080",
+		"APP_COMMAND":   "echo $${APP_HOST}",
+	}
+	if !reflect.DeepEqual(variables, expected) {
+		t.Errorf("expected: %q, actual: %q", expected, variables)
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	tests := map[string]string{
+		"APP_HOST":               "line 1",
+		"A=1\nAPP_HOST='value":   "line 2",
+		"APP_HOST=\"value":       "line 1",
+		"APP_HOST='value' extra": "line 1",
+		"=value":                 "line 1",
+	}
+	for raw, line := range tests {
+		if _, err := parseDotenv(raw); err == nil {
+			t.Errorf("there must be an error for %q", raw)
+		} else if !strings.HasPrefix(err.Error(), line) {
+			t.Errorf("invalid error for %q: %s", raw, err)
+		}
+	}
+}
+
+func TestDotenvLoad(t *testing.T) {
+	provider := NewDotenvDataProvider([]byte("APP_HOST=localhost\nAPP_PORT=8081\nAPP_SERVER_PARAMS_0_LABEL=\"${APP_HOST} first\"\n"), "APP")
+	provider.SetSchema(envSchema)
+	result, err := provider.Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"host": "localhost",
+		"port": int64(8081),
+		"server": map[string]interface{}{
+			"params": []interface{}{map[string]interface{}{"label": "localhost first"}},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, result)
+	}
+
+	if _, err := NewDotenvDataProvider(nil, "APP").Load(); err == nil {
+		t.Error("there must be an error without schema")
+	}
+}
+
+func TestDotenvUnload(t *testing.T) {
+	provider := NewDotenvDataProvider(nil, "APP")
+	if err := provider.Unload(map[string]interface{}{"host": "string required"}); err != nil {
+		t.Fatal(err)
+	}
+	if string(provider.Data().([]byte)) != "# string required\nAPP_HOST=\n" {
+		t.Errorf("unexpected value: %s", provider.Data())
+	}
+}
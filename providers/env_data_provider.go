@@ -0,0 +1,165 @@
+package providers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Provider of environment variables, names of variables are built from prefix
+// and path of field e.g. APP_SERVER_NAME, APP_SERVER_PARAMS_0_LABEL
+type EnvDataProvider struct {
+	prefix  string
+	environ func() []string
+	schema  *Schema
+	data    []byte
+}
+
+// Create provider of process environment
+func NewEnvDataProvider(prefix string) *EnvDataProvider {
+	return &EnvDataProvider{
+		prefix:  prefix,
+		environ: os.Environ,
+	}
+}
+
+// Set schema of reflector
+func (provider *EnvDataProvider) SetSchema(schema *Schema) {
+	provider.schema = schema
+}
+
+func (provider *EnvDataProvider) Load() (map[string]interface{}, error) {
+	if provider.schema == nil {
+		return nil, errors.New("schema is required to load environment variables")
+	}
+	variables := map[string]string{}
+	for _, variable := range provider.environ() {
+		if index := strings.Index(variable, "="); index > 0 {
+			variables[variable[:index]] = variable[index+1:]
+		}
+	}
+	return loadVariables(variables, provider.prefix, provider.schema.Fields)
+}
+
+// Unload documentation of recognised variables
+func (provider *EnvDataProvider) Unload(data map[string]interface{}) error {
+	provider.data = documentVariables(provider.prefix, data)
+	return nil
+}
+
+func (provider *EnvDataProvider) Data() interface{} {
+	return provider.data
+}
+
+// Name of variable for nested key
+func variableName(name string, key string) string {
+	key = strings.ToUpper(key)
+	if name == "" {
+		return key
+	}
+	return name + "_" + key
+}
+
+// Load values of variables according to schemas of fields
+func loadVariables(variables map[string]string, prefix string, fields map[string]*Schema) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for key, schema := range fields {
+		name := variableName(prefix, key)
+		value, found, err := loadVariable(variables, name, schema)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+// Load value of variable (or group of variables for objects, maps and arrays of objects)
+func loadVariable(variables map[string]string, name string, schema *Schema) (interface{}, bool, error) {
+	switch schema.Kind {
+	case ArrayKind:
+		if schema.Elem.Kind == ObjectKind {
+			// array of objects uses index in names of variables
+			result := []interface{}{}
+			for index := 0; ; index++ {
+				value, err := loadVariables(variables, variableName(name, strconv.Itoa(index)), schema.Elem.Fields)
+				if err != nil {
+					return nil, false, err
+				}
+				if len(value) == 0 {
+					break
+				}
+				result = append(result, value)
+			}
+			return result, len(result) > 0, nil
+		}
+	case MapKind:
+		result, err := loadMapVariables(variables, name, schema.Elem)
+		return result, len(result) > 0, err
+	case ObjectKind:
+		result, err := loadVariables(variables, name, schema.Fields)
+		return result, len(result) > 0, err
+	}
+
+	raw, ok := variables[name]
+	if !ok {
+		return nil, false, nil
+	}
+	value, err := parseValue(raw, schema)
+	if err != nil {
+		return nil, false, errors.New(fmt.Sprintf("invalid value of variable %s: %s", name, err))
+	}
+	return value, true, nil
+}
+
+// Load map field, keys of map are taken from names of variables
+func loadMapVariables(variables map[string]string, name string, schema *Schema) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for variable := range variables {
+		if !strings.HasPrefix(variable, name+"_") {
+			continue
+		}
+		key := variable[len(name)+1:]
+		if schema.Kind == ObjectKind {
+			// key is followed by name of object field
+			for field := range schema.Fields {
+				if suffix := "_" + strings.ToUpper(field); strings.HasSuffix(key, suffix) && len(key) > len(suffix) {
+					key = key[:len(key)-len(suffix)]
+					break
+				}
+			}
+		}
+		key = strings.ToLower(key)
+		if _, ok := result[key]; ok {
+			continue
+		}
+		value, found, err := loadVariable(variables, variableName(name, key), schema)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+// Document variables of template
+func documentVariables(prefix string, data map[string]interface{}) []byte {
+	lines := map[string]string{}
+	documentTemplate(lines, prefix, data, variableName)
+
+	var buffer bytes.Buffer
+	for _, name := range sortedKeys(lines) {
+		if lines[name] != "" {
+			buffer.WriteString("# " + lines[name] + "\n")
+		}
+		buffer.WriteString(name + "=\n")
+	}
+	return buffer.Bytes()
+}
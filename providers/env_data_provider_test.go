@@ -0,0 +1,135 @@
+package providers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var envSchema = &Schema{Kind: ObjectKind, Fields: map[string]*Schema{
+	"host":   {Kind: StringKind},
+	"port":   {Kind: IntKind},
+	"ratio":  {Kind: FloatKind},
+	"debug":  {Kind: BoolKind},
+	"tags":   {Kind: ArrayKind, Elem: &Schema{Kind: StringKind}},
+	"ports":  {Kind: ArrayKind, Elem: &Schema{Kind: UintKind}},
+	"labels": {Kind: MapKind, Elem: &Schema{Kind: StringKind}},
+	"backends": {Kind: MapKind, Elem: &Schema{Kind: ObjectKind, Fields: map[string]*Schema{
+		"host":   {Kind: StringKind},
+		"weight": {Kind: FloatKind},
+	}}},
+	"server": {Kind: ObjectKind, Fields: map[string]*Schema{
+		"name": {Kind: StringKind},
+		"params": {Kind: ArrayKind, Elem: &Schema{Kind: ObjectKind, Fields: map[string]*Schema{
+			"label": {Kind: StringKind},
+		}}},
+	}},
+}}
+
+// Data unloaded by reflector template
+var envTemplate = map[string]interface{}{
+	"host":   "string required",
+	"port":   "int default 8080",
+	"ratio":  "float",
+	"debug":  "bool",
+	"tags":   []interface{}{"string"},
+	"ports":  []interface{}{"uint"},
+	"labels": map[string]interface{}{"{key}": "string"},
+	"backends": map[string]interface{}{"{key}": map[string]interface{}{
+		"host":   "string",
+		"weight": "float",
+	}},
+	"server": map[string]interface{}{
+		"name": "string",
+		"params": []interface{}{map[string]interface{}{
+			"label": "string",
+		}},
+	},
+}
+
+func newTestEnvProvider(variables ...string) *EnvDataProvider {
+	provider := NewEnvDataProvider("APP")
+	provider.environ = func() []string {
+		return variables
+	}
+	provider.SetSchema(envSchema)
+	return provider
+}
+
+func TestEnvLoad(t *testing.T) {
+	provider := newTestEnvProvider(
+		"APP_HOST=localhost",
+		"APP_PORT=8081",
+		"APP_RATIO=0.5",
+		"APP_DEBUG=yes",
+		"APP_TAGS=a, b,c",
+		"APP_PORTS=80,443",
+		"APP_LABELS_ENV=prod",
+		"APP_BACKENDS_FIRST_HOST=10.0.0.1",
+		"APP_BACKENDS_FIRST_WEIGHT=2",
+		"APP_SERVER_NAME=main",
+		"APP_SERVER_PARAMS_0_LABEL=first",
+		"APP_SERVER_PARAMS_1_LABEL=second",
+		"APP_SERVER_PARAMS_3_LABEL=skipped",
+		"OTHER_HOST=other",
+	)
+	data, err := provider.Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	result, _ := json.Marshal(data)
+	expected := `{"backends":{"first":{"host":"10.0.0.1","weight":2}},"debug":true,"host":"localhost",` +
+		`"labels":{"env":"prod"},"port":8081,"ports":[80,443],"ratio":0.5,` +
+		`"server":{"name":"main","params":[{"label":"first"},{"label":"second"}]},"tags":["a","b","c"]}`
+	if string(result) != expected {
+		t.Errorf("expected: %s, actual: %s", expected, result)
+	}
+	if _, ok := data["port"].(int64); !ok {
+		t.Errorf("invalid type of port: %T", data["port"])
+	}
+}
+
+func TestEnvInvalidValue(t *testing.T) {
+	tests := []string{"APP_PORT=80s", "APP_DEBUG=maybe", "APP_PORTS=1,-1"}
+	for _, test := range tests {
+		if _, err := newTestEnvProvider(test).Load(); err == nil {
+			t.Errorf("there must be an error for %s", test)
+		}
+	}
+}
+
+func TestEnvWithoutSchema(t *testing.T) {
+	if _, err := NewEnvDataProvider("APP").Load(); err == nil {
+		t.Error("there must be an error without schema")
+	}
+}
+
+func TestEnvProcessEnvironment(t *testing.T) {
+	t.Setenv("TEST_REFLECTOR_HOST", "localhost")
+	provider := NewEnvDataProvider("TEST_REFLECTOR")
+	provider.SetSchema(&Schema{Kind: ObjectKind, Fields: map[string]*Schema{"host": {Kind: StringKind}}})
+	if data, err := provider.Load(); err != nil {
+		t.Error(err)
+	} else if data["host"] != "localhost" {
+		t.Errorf("invalid value: %v", data["host"])
+	}
+}
+
+func TestEnvUnload(t *testing.T) {
+	provider := NewEnvDataProvider("APP")
+	if err := provider.Unload(envTemplate); err != nil {
+		t.Fatal(err)
+	}
+	data := string(provider.Data().([]byte))
+	for _, line := range []string{
+		"# string\nAPP_BACKENDS_{KEY}_HOST=\n",
+		"# int default 8080\nAPP_PORT=\n",
+		"# comma separated list of string\nAPP_TAGS=\n",
+		"# string\nAPP_LABELS_{KEY}=\n",
+		"# string\nAPP_SERVER_PARAMS_{INDEX}_LABEL=\n",
+	} {
+		if !strings.Contains(data, line) {
+			t.Errorf("there is no %q in documentation:\n%s", line, data)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// Provider of configuration file, format is chosen by extension or content
+type FileDataProvider struct {
+	fsys   fs.FS // nil for files of operating system
+	path   string
+	format *Format
+	schema *Schema
+	data   []byte
+}
+
+// Create provider of file in operating system
+func NewFileDataProvider(path string) *FileDataProvider {
+	return &FileDataProvider{
+		path: path,
+	}
+}
+
+// Create provider of file in file system e.g. embed.FS
+func NewFSDataProvider(fsys fs.FS, path string) *FileDataProvider {
+	return &FileDataProvider{
+		fsys: fsys,
+		path: path,
+	}
+}
+
+// Use format instead of detection
+func (provider *FileDataProvider) SetFormat(format *Format) *FileDataProvider {
+	provider.format = format
+	return provider
+}
+
+// Set schema of reflector for formats which need it
+func (provider *FileDataProvider) SetSchema(schema *Schema) {
+	provider.schema = schema
+}
+
+func (provider *FileDataProvider) Load() (map[string]interface{}, error) {
+	var data []byte
+	var err error
+	if provider.fsys != nil {
+		data, err = fs.ReadFile(provider.fsys, provider.path)
+	} else {
+		data, err = os.ReadFile(provider.path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	provider.data = data
+
+	format := provider.detectFormat(data)
+	if format == nil {
+		return nil, errors.New(fmt.Sprintf("%s: unknown format of file", provider.path))
+	}
+	formatProvider := format.New(data)
+	if schemaProvider, ok := formatProvider.(SchemaDataProvider); ok && provider.schema != nil {
+		schemaProvider.SetSchema(provider.schema)
+	}
+	result, err := formatProvider.Load()
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("%s: %s: %s", provider.path, format.Name, err))
+	}
+	return result, nil
+}
+
+// Unload data in format of file, file is not changed until Save
+func (provider *FileDataProvider) Unload(data map[string]interface{}) error {
+	format := provider.detectFormat(nil)
+	if format == nil {
+		return errors.New(fmt.Sprintf("%s: unknown format of file", provider.path))
+	}
+	formatProvider := format.New(nil)
+	if err := formatProvider.Unload(data); err != nil {
+		return errors.New(fmt.Sprintf("%s: %s: %s", provider.path, format.Name, err))
+	}
+	result, ok := formatProvider.Data().([]byte)
+	if !ok {
+		return errors.New(fmt.Sprintf("%s: %s provider does not return bytes", provider.path, format.Name))
+	}
+	provider.data = result
+	return nil
+}
+
+// Write unloaded data to file of operating system
+func (provider *FileDataProvider) Save() error {
+	if provider.fsys != nil {
+		return errors.New(fmt.Sprintf("%s: file system is read only", provider.path))
+	}
+	if provider.data == nil {
+		return errors.New(fmt.Sprintf("%s: there is no data to save", provider.path))
+	}
+	return os.WriteFile(provider.path, provider.data, 0644)
+}
+
+// Content of file or unloaded data
+func (provider *FileDataProvider) Data() interface{} {
+	return provider.data
+}
+
+// Format set by user, found by extension or detected by content
+func (provider *FileDataProvider) detectFormat(data []byte) *Format {
+	if provider.format != nil {
+		return provider.format
+	}
+	if format := FormatByPath(provider.path); format != nil {
+		return format
+	}
+	if data != nil {
+		return DetectFormat(data)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package providers
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+func TestFileLoadByExtension(t *testing.T) {
+	provider := NewFileDataProvider(filepath.Join("testdata", "config.yaml"))
+	result, err := provider.Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"host":   "localhost",
+		"port":   int64(8080),
+		"server": map[string]interface{}{"name": "main"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, result)
+	}
+}
+
+func TestFileLoadByContent(t *testing.T) {
+	provider := NewFSDataProvider(testdata, "testdata/config")
+	result, err := provider.Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	if result["port"] != 8080.0 {
+		t.Errorf("json must be detected: %v", result)
+	}
+}
+
+func TestFileWithSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.properties")
+	if err := os.WriteFile(path, []byte("port=8080\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	provider := NewFileDataProvider(path)
+	provider.SetSchema(&Schema{Kind: ObjectKind, Fields: map[string]*Schema{"port": {Kind: IntKind}}})
+	if result, err := provider.Load(); err != nil {
+		t.Error(err)
+	} else if result["port"] != int64(8080) {
+		t.Errorf("invalid value: %v", result["port"])
+	}
+}
+
+func TestFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	unknown := filepath.Join(dir, "unknown")
+	if err := os.WriteFile(unknown, []byte("- a\n- b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{invalid, unknown, filepath.Join(dir, "missing.json")} {
+		if _, err := NewFileDataProvider(path).Load(); err == nil {
+			t.Errorf("there must be an error for %s", path)
+		} else if !strings.Contains(err.Error(), path) {
+			t.Errorf("error must contain path: %s", err)
+		}
+	}
+}
+
+func TestFileUnload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	provider := NewFileDataProvider(path)
+	if err := provider.Unload(map[string]interface{}{"host": "string required"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "host = \"string required\"\n"
+	if string(provider.Data().([]byte)) != expected {
+		t.Errorf("unexpected value: %s", provider.Data())
+	}
+	// unload does not write file
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("file must not be written by unload: %v", err)
+	}
+	if err := provider.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(path); err != nil {
+		t.Error(err)
+	} else if string(data) != expected {
+		t.Errorf("unexpected content of file: %s", data)
+	}
+
+	// file system is read only
+	provider = NewFSDataProvider(testdata, "testdata/config.yaml")
+	if err := provider.Unload(map[string]interface{}{"host": "string"}); err != nil {
+		t.Error(err)
+	} else if string(provider.Data().([]byte)) != "host: string\n" {
+		t.Errorf("unexpected value: %s", provider.Data())
+	}
+	if err := provider.Save(); err == nil {
+		t.Error("there must be an error for read only file system")
+	}
+}
+
+func TestFormats(t *testing.T) {
+	if FormatByPath("config.YML").Name != "yaml" || FormatByPath(".env").Name != "dotenv" {
+		t.Error("invalid format by extension")
+	}
+	if FormatByPath("config.xml") != nil || FormatByName("xml") != nil {
+		t.Error("xml is not registered")
+	}
+	tests := map[string]string{
+		`{"host":"localhost"}`: "json",
+		"host = 'localhost'":   "toml",
+		"host: localhost":      "yaml",
+	}
+	for data, name := range tests {
+		if format := DetectFormat([]byte(data)); format == nil || format.Name != name {
+			t.Errorf("%s must be detected as %s", data, name)
+		}
+	}
+}
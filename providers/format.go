@@ -0,0 +1,138 @@
+package providers
+
+import (
+	"bytes"
+	"mime"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Format of configuration data
+type Format struct {
+	Name       string
+	Extensions []string                       // extensions of files e.g. .yaml
+	MediaTypes []string                       // media types of HTTP content e.g. application/yaml
+	Sniff      func(data []byte) bool         // detect format by content, can be nil
+	New        func(data []byte) DataProvider // create provider for data
+}
+
+var (
+	formatsMutex sync.RWMutex
+	formats      []*Format
+)
+
+// Built-in formats, json is checked first by content
+func init() {
+	RegisterFormat(&Format{
+		Name:       "dotenv",
+		MediaTypes: []string{"text/x-dotenv"},
+		Extensions: []string{".env"},
+		New:        func(data []byte) DataProvider { return NewDotenvDataProvider(data, "") },
+	})
+	RegisterFormat(&Format{
+		Name:       "properties",
+		MediaTypes: []string{"text/x-java-properties"},
+		Extensions: []string{".properties"},
+		New:        func(data []byte) DataProvider { return NewPropertiesDataProvider(data) },
+	})
+	RegisterFormat(&Format{
+		Name:       "ini",
+		MediaTypes: []string{"text/x-ini"},
+		Extensions: []string{".ini", ".cfg"},
+		New:        func(data []byte) DataProvider { return NewIniDataProvider(data) },
+	})
+	RegisterFormat(&Format{
+		Name:       "yaml",
+		MediaTypes: []string{"application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml"},
+		Extensions: []string{".yaml", ".yml"},
+		Sniff: func(data []byte) bool {
+			result, err := NewYamlDataProvider(data).Load()
+			return err == nil && len(result) > 0
+		},
+		New: func(data []byte) DataProvider { return NewYamlDataProvider(data) },
+	})
+	RegisterFormat(&Format{
+		Name:       "toml",
+		MediaTypes: []string{"application/toml"},
+		Extensions: []string{".toml"},
+		Sniff: func(data []byte) bool {
+			_, err := NewTomlDataProvider(data).Load()
+			return err == nil
+		},
+		New: func(data []byte) DataProvider { return NewTomlDataProvider(data) },
+	})
+	RegisterFormat(&Format{
+		Name:       "json",
+		MediaTypes: []string{"application/json"},
+		Extensions: []string{".json"},
+		Sniff: func(data []byte) bool {
+			return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+		},
+		New: func(data []byte) DataProvider { return NewJsonDataProvider(data) },
+	})
+}
+
+// Register format, formats registered later have higher priority
+func RegisterFormat(format *Format) {
+	formatsMutex.Lock()
+	defer formatsMutex.Unlock()
+	formats = append([]*Format{format}, formats...)
+}
+
+// Find format by name, nil if format is not registered
+func FormatByName(name string) *Format {
+	formatsMutex.RLock()
+	defer formatsMutex.RUnlock()
+	for _, format := range formats {
+		if format.Name == name {
+			return format
+		}
+	}
+	return nil
+}
+
+// Find format by extension of file name, nil if format is not registered
+func FormatByPath(name string) *Format {
+	extension := strings.ToLower(path.Ext(name))
+	formatsMutex.RLock()
+	defer formatsMutex.RUnlock()
+	for _, format := range formats {
+		for _, e := range format.Extensions {
+			if e == extension {
+				return format
+			}
+		}
+	}
+	return nil
+}
+
+// Find format by media type of Content-Type header, nil if format is not registered
+func FormatByMediaType(contentType string) *Format {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+	formatsMutex.RLock()
+	defer formatsMutex.RUnlock()
+	for _, format := range formats {
+		for _, t := range format.MediaTypes {
+			if t == mediaType {
+				return format
+			}
+		}
+	}
+	return nil
+}
+
+// Detect format by content, nil if format is unknown
+func DetectFormat(data []byte) *Format {
+	formatsMutex.RLock()
+	defer formatsMutex.RUnlock()
+	for _, format := range formats {
+		if format.Sniff != nil && format.Sniff(data) {
+			return format
+		}
+	}
+	return nil
+}
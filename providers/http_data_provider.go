@@ -0,0 +1,167 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Provider of remote configuration loaded by HTTP GET, format is chosen by Content-Type,
+// unchanged content is detected by ETag and taken from cache
+type HttpDataProvider struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+	retries int
+	backoff time.Duration
+	schema  *Schema
+	etag    string
+	format  *Format
+	body    []byte // cached content of response for etag
+	data    []byte // content of last response or unloaded data
+}
+
+func NewHttpDataProvider(url string) *HttpDataProvider {
+	return &HttpDataProvider{
+		url:     url,
+		client:  http.DefaultClient,
+		timeout: 30 * time.Second,
+		backoff: 100 * time.Millisecond,
+	}
+}
+
+// Use client for requests
+func (provider *HttpDataProvider) SetClient(client *http.Client) *HttpDataProvider {
+	provider.client = client
+	return provider
+}
+
+// Set timeout of Load, LoadContext uses deadline of context
+func (provider *HttpDataProvider) SetTimeout(timeout time.Duration) *HttpDataProvider {
+	provider.timeout = timeout
+	return provider
+}
+
+// Set number of retries for network errors and 5xx/429 responses, backoff is doubled after each retry
+func (provider *HttpDataProvider) SetRetries(retries int, backoff time.Duration) *HttpDataProvider {
+	provider.retries = retries
+	provider.backoff = backoff
+	return provider
+}
+
+// Set schema of reflector for formats which need it
+func (provider *HttpDataProvider) SetSchema(schema *Schema) {
+	provider.schema = schema
+}
+
+func (provider *HttpDataProvider) Load() (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), provider.timeout)
+	defer cancel()
+	return provider.LoadContext(ctx)
+}
+
+// Load with context for cancellation and timeout
+func (provider *HttpDataProvider) LoadContext(ctx context.Context) (map[string]interface{}, error) {
+	backoff := provider.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := provider.fetch(ctx)
+		if err == nil {
+			break
+		}
+		if !retry || attempt >= provider.retries {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.New(fmt.Sprintf("%s: %s", provider.url, ctx.Err()))
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+
+	provider.data = provider.body
+	formatProvider := provider.format.New(provider.body)
+	if schemaProvider, ok := formatProvider.(SchemaDataProvider); ok && provider.schema != nil {
+		schemaProvider.SetSchema(provider.schema)
+	}
+	result, err := formatProvider.Load()
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("%s: %s: %s", provider.url, provider.format.Name, err))
+	}
+	return result, nil
+}
+
+// Unload data in format of remote configuration, json is used if format is unknown
+func (provider *HttpDataProvider) Unload(data map[string]interface{}) error {
+	format := provider.format
+	if format == nil {
+		if u, err := url.Parse(provider.url); err == nil {
+			format = FormatByPath(u.Path)
+		}
+	}
+	if format == nil {
+		format = FormatByName("json")
+	}
+	formatProvider := format.New(nil)
+	if err := formatProvider.Unload(data); err != nil {
+		return err
+	}
+	result, ok := formatProvider.Data().([]byte)
+	if !ok {
+		return errors.New(fmt.Sprintf("%s provider does not return bytes", format.Name))
+	}
+	provider.data = result
+	return nil
+}
+
+// Content of last response or unloaded data
+func (provider *HttpDataProvider) Data() interface{} {
+	return provider.data
+}
+
+// Get content, returns true if request can be retried
+func (provider *HttpDataProvider) fetch(ctx context.Context) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, provider.url, nil)
+	if err != nil {
+		return false, err
+	}
+	if provider.etag != "" {
+		request.Header.Set("If-None-Match", provider.etag)
+	}
+	response, err := provider.client.Do(request)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusNotModified && provider.etag != "":
+		// cached content is not changed
+		return false, nil
+	case response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500:
+		return true, errors.New(fmt.Sprintf("%s: unexpected status %s", provider.url, response.Status))
+	case response.StatusCode != http.StatusOK:
+		return false, errors.New(fmt.Sprintf("%s: unexpected status %s", provider.url, response.Status))
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return true, err
+	}
+	format := FormatByMediaType(response.Header.Get("Content-Type"))
+	if format == nil {
+		format = FormatByPath(request.URL.Path)
+	}
+	if format == nil {
+		format = DetectFormat(data)
+	}
+	if format == nil {
+		return false, errors.New(fmt.Sprintf("%s: unknown format of content", provider.url))
+	}
+	provider.body, provider.format, provider.etag = data, format, response.Header.Get("ETag")
+	return false, nil
+}
@@ -0,0 +1,134 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHttpLoad(t *testing.T) {
+	var requests, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("host: localhost\nport: 8080\n"))
+	}))
+	defer server.Close()
+
+	provider := NewHttpDataProvider(server.URL + "/config")
+	for i := 0; i < 2; i++ {
+		result, err := provider.Load()
+		if err != nil {
+			t.Fatalf("there can not be an error: %s", err)
+		}
+		if result["host"] != "localhost" || result["port"] != int64(8080) {
+			t.Errorf("invalid values: %v", result)
+		}
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("second request must use etag: %d requests, %d not modified", requests, notModified)
+	}
+
+	// template in format of remote configuration
+	if err := provider.Unload(map[string]interface{}{"host": "string"}); err != nil {
+		t.Error(err)
+	} else if string(provider.Data().([]byte)) != "host: string\n" {
+		t.Errorf("unexpected value: %s", provider.Data())
+	}
+
+	// unloaded data does not replace cached response
+	if result, err := provider.Load(); err != nil {
+		t.Error(err)
+	} else if result["host"] != "localhost" {
+		t.Errorf("invalid values after unload: %v", result)
+	}
+	if notModified != 2 {
+		t.Errorf("request must use etag after unload: %d not modified", notModified)
+	}
+	if string(provider.Data().([]byte)) != "host: localhost\nport: 8080\n" {
+		t.Errorf("unexpected content: %s", provider.Data())
+	}
+}
+
+func TestHttpSchema(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/x-java-properties")
+		_, _ = w.Write([]byte("port=8080\n"))
+	}))
+	defer server.Close()
+
+	provider := NewHttpDataProvider(server.URL)
+	provider.SetSchema(&Schema{Kind: ObjectKind, Fields: map[string]*Schema{"port": {Kind: IntKind}}})
+	if result, err := provider.Load(); err != nil {
+		t.Error(err)
+	} else if result["port"] != int64(8080) {
+		t.Errorf("invalid value: %v", result["port"])
+	}
+}
+
+func TestHttpRetries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"host":"localhost"}`))
+	}))
+	defer server.Close()
+
+	provider := NewHttpDataProvider(server.URL).SetRetries(2, time.Millisecond)
+	if result, err := provider.Load(); err != nil {
+		t.Error(err)
+	} else if result["host"] != "localhost" {
+		t.Errorf("invalid value: %v", result["host"])
+	}
+
+	requests = 0
+	provider = NewHttpDataProvider(server.URL).SetRetries(1, time.Millisecond)
+	if _, err := provider.Load(); err == nil {
+		t.Error("there must be an error after retries")
+	}
+}
+
+func TestHttpErrors(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		case "/unknown":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte("- a\n- b\n"))
+		}
+	}))
+	defer server.Close()
+
+	if _, err := NewHttpDataProvider(server.URL+"/missing").SetRetries(3, time.Millisecond).Load(); err == nil {
+		t.Error("there must be an error for missing config")
+	} else if requests != 1 {
+		t.Errorf("client errors must not be retried: %d requests", requests)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := NewHttpDataProvider(server.URL + "/slow").LoadContext(ctx); err == nil {
+		t.Error("there must be an error for timeout")
+	}
+
+	if _, err := NewHttpDataProvider(server.URL + "/unknown").Load(); err == nil {
+		t.Error("there must be an error for unknown format")
+	}
+}
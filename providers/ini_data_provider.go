@@ -0,0 +1,150 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Provider of INI files, sections are nested objects (e.g. [server.params.0]),
+// values are parsed according to schema of reflector, comments start with ; or #
+type IniDataProvider struct {
+	data   []byte
+	schema *Schema
+}
+
+func NewIniDataProvider(data []byte) *IniDataProvider {
+	return &IniDataProvider{
+		data: data,
+	}
+}
+
+// Set schema of reflector
+func (provider *IniDataProvider) SetSchema(schema *Schema) {
+	provider.schema = schema
+}
+
+func (provider *IniDataProvider) Load() (map[string]interface{}, error) {
+	tree := map[string]interface{}{}
+	var section []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(provider.data))
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			line = stripInlineComment(line)
+			if line[len(line)-1] != ']' {
+				return nil, errors.New(fmt.Sprintf("line %d: invalid section `%s`", number, line))
+			}
+			section = splitKey(line[1 : len(line)-1])
+			continue
+		}
+		index := strings.IndexAny(line, "=:")
+		if index < 1 {
+			return nil, errors.New(fmt.Sprintf("line %d: invalid key value pair `%s`", number, line))
+		}
+		path := append(append([]string{}, section...), splitKey(line[:index])...)
+		if err := setTreeValue(tree, path, unquote(stripInlineComment(strings.TrimSpace(line[index+1:])))); err != nil {
+			return nil, errors.New(fmt.Sprintf("line %d: %s", number, err))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result, err := typedTree(tree, provider.schema, "")
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]interface{}), nil
+}
+
+// Unload commented template, nested objects are written as sections
+func (provider *IniDataProvider) Unload(data map[string]interface{}) error {
+	lines := map[string]string{}
+	documentTemplate(lines, "", data, dottedName)
+
+	// group keys by sections
+	sections := map[string]map[string]string{}
+	for name, comment := range lines {
+		section, key := "", name
+		if index := strings.LastIndex(name, "."); index >= 0 {
+			section, key = name[:index], name[index+1:]
+		}
+		if sections[section] == nil {
+			sections[section] = map[string]string{}
+		}
+		sections[section][key] = comment
+	}
+
+	var buffer bytes.Buffer
+	for _, section := range sortedSections(sections) {
+		if section != "" {
+			if buffer.Len() > 0 {
+				buffer.WriteString("\n")
+			}
+			buffer.WriteString("[" + section + "]\n")
+		}
+		for _, key := range sortedKeys(sections[section]) {
+			if comment := sections[section][key]; comment != "" {
+				buffer.WriteString("; " + comment + "\n")
+			}
+			buffer.WriteString(key + " =\n")
+		}
+	}
+	provider.data = buffer.Bytes()
+	return nil
+}
+
+func (provider *IniDataProvider) Data() interface{} {
+	return provider.data
+}
+
+// Split dotted key
+func splitKey(key string) []string {
+	path := strings.Split(key, ".")
+	for index := range path {
+		path[index] = strings.TrimSpace(path[index])
+	}
+	return path
+}
+
+// Remove quotes around value
+// Remove comment after value, comment starts by ; or # at beginning of value
+// or after white space, characters of quoted value are kept
+func stripInlineComment(value string) string {
+	start := 0
+	if value != "" && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			start = end + 2
+		}
+	}
+	for index := start; index < len(value); index++ {
+		if (value[index] == ';' || value[index] == '#') &&
+			(index == 0 || value[index-1] == ' ' || value[index-1] == '\t') {
+			return strings.TrimSpace(value[:index])
+		}
+	}
+	return value
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// Sections in order of names, section without name is first
+func sortedSections(sections map[string]map[string]string) []string {
+	names := make(map[string]string, len(sections))
+	for name := range sections {
+		names[name] = name
+	}
+	return sortedKeys(names)
+}
@@ -0,0 +1,178 @@
+package providers
+
+import (
+	"reflect"
+	"testing"
+)
+
+var iniSchema = &Schema{Kind: ObjectKind, Fields: map[string]*Schema{
+	"host":  {Kind: StringKind},
+	"port":  {Kind: IntKind},
+	"debug": {Kind: BoolKind},
+	"tags":  {Kind: ArrayKind, Elem: &Schema{Kind: StringKind}},
+	"server": {Kind: ObjectKind, Fields: map[string]*Schema{
+		"name":   {Kind: StringKind},
+		"labels": {Kind: MapKind, Elem: &Schema{Kind: StringKind}},
+		"params": {Kind: ArrayKind, Elem: &Schema{Kind: ObjectKind, Fields: map[string]*Schema{
+			"label":  {Kind: StringKind},
+			"weight": {Kind: FloatKind},
+		}}},
+	}},
+}}
+
+// Data unloaded by reflector template
+var iniTemplate = map[string]interface{}{
+	"host":  "string required",
+	"port":  "int default 8080",
+	"debug": "bool",
+	"tags":  []interface{}{"string"},
+	"server": map[string]interface{}{
+		"name":   "string",
+		"labels": map[string]interface{}{"{key}": "string"},
+		"params": []interface{}{map[string]interface{}{
+			"label":  "string",
+			"weight": "float",
+		}},
+	},
+}
+
+func TestIniLoad(t *testing.T) {
+	raw := []byte(`
+; global values
+host = localhost
+port = 8081
+debug = on
+tags = "a, b"
+
+[server]
+name = 'main'
+labels.env = prod
+
+[server.params.1]
+label = second
+
+[server.params.0]
+label = first
+weight = 0.5
+`)
+	provider := NewIniDataProvider(raw)
+	provider.SetSchema(iniSchema)
+	result, err := provider.Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"host":  "localhost",
+		"port":  int64(8081),
+		"debug": true,
+		"tags":  []interface{}{"a", "b"},
+		"server": map[string]interface{}{
+			"name":   "main",
+			"labels": map[string]interface{}{"env": "prod"},
+			"params": []interface{}{
+				map[string]interface{}{"label": "first", "weight": 0.5},
+				map[string]interface{}{"label": "second"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, result)
+	}
+}
+
+func TestIniInlineComments(t *testing.T) {
+	raw := []byte(`
+port = 8080 ; http port
+host = localhost # primary host
+empty = ; not set
+[server] ; main server
+name = "main ; # server" ; quoted value
+url = http://host/#anchor;x
+`)
+	result, err := NewIniDataProvider(raw).Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"port":  "8080",
+		"host":  "localhost",
+		"empty": "",
+		"server": map[string]interface{}{
+			"name": "main ; # server",
+			"url":  "http://host/#anchor;x",
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, result)
+	}
+
+	provider := NewIniDataProvider([]byte("port = 8080 ; http port"))
+	provider.SetSchema(iniSchema)
+	if result, err := provider.Load(); err != nil || result["port"] != int64(8080) {
+		t.Errorf("invalid value with comment: %v, %v", result, err)
+	}
+}
+
+func TestIniWithoutSchema(t *testing.T) {
+	result, err := NewIniDataProvider([]byte("port = 8080\n[server]\nname = main")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"port":   "8080",
+		"server": map[string]interface{}{"name": "main"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, result)
+	}
+}
+
+func TestIniInvalidFormat(t *testing.T) {
+	tests := []string{
+		"[server",
+		"host",
+		"server = main\n[server]\nname = main",
+		"port = 80s",
+		"[server.params.first]\nlabel = a",
+	}
+	for _, test := range tests {
+		provider := NewIniDataProvider([]byte(test))
+		provider.SetSchema(iniSchema)
+		if _, err := provider.Load(); err == nil {
+			t.Errorf("there must be an error for %q", test)
+		}
+	}
+}
+
+func TestIniUnload(t *testing.T) {
+	provider := NewIniDataProvider(nil)
+	if err := provider.Unload(iniTemplate); err != nil {
+		t.Fatal(err)
+	}
+	expected := `; bool
+debug =
+; string required
+host =
+; int default 8080
+port =
+; comma separated list of string
+tags =
+
+[server]
+; string
+name =
+
+[server.labels]
+; string
+{key} =
+
+[server.params.{index}]
+; string
+label =
+; float
+weight =
+`
+	if string(provider.Data().([]byte)) != expected {
+		t.Errorf("unexpected value:\n%s", provider.Data())
+	}
+}
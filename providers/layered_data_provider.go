@@ -0,0 +1,149 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Strategy of merging slices from different layers
+type SliceMergeStrategy int
+
+const (
+	ReplaceSlices    SliceMergeStrategy = iota // slice of upper layer replaces lower one
+	AppendSlices                               // elements of upper layer are appended
+	MergeSlicesByKey                           // objects with same value of key are merged, others are appended
+)
+
+// Strategy of merging nested objects from different layers
+type ObjectMergeStrategy int
+
+const (
+	DeepMergeObjects ObjectMergeStrategy = iota // keys of objects are merged recursively
+	ReplaceObjects                              // object of upper layer replaces lower one
+)
+
+// Provider which merges data of several providers, each next layer has higher precedence
+type LayeredDataProvider struct {
+	layers         []DataProvider
+	sliceStrategy  SliceMergeStrategy
+	mergeKey       string
+	objectStrategy ObjectMergeStrategy
+	data           map[string]interface{}
+}
+
+// Create layered provider, layers are ordered by precedence e.g. defaults, file, environment, command line
+func NewLayeredDataProvider(layers ...DataProvider) *LayeredDataProvider {
+	return &LayeredDataProvider{
+		layers: layers,
+	}
+}
+
+// Set strategy for slices, key is used only by MergeSlicesByKey
+func (provider *LayeredDataProvider) SetSliceStrategy(strategy SliceMergeStrategy, key string) *LayeredDataProvider {
+	provider.sliceStrategy = strategy
+	provider.mergeKey = key
+	return provider
+}
+
+// Set strategy for nested objects
+func (provider *LayeredDataProvider) SetObjectStrategy(strategy ObjectMergeStrategy) *LayeredDataProvider {
+	provider.objectStrategy = strategy
+	return provider
+}
+
+// Set schema of reflector for layers which need it
+func (provider *LayeredDataProvider) SetSchema(schema *Schema) {
+	for _, layer := range provider.layers {
+		if layer, ok := layer.(SchemaDataProvider); ok {
+			layer.SetSchema(schema)
+		}
+	}
+}
+
+func (provider *LayeredDataProvider) Load() (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for index, layer := range provider.layers {
+		data, err := layer.Load()
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("error load layer %d (%T): %s", index, layer, err))
+		}
+		result = provider.mergeObjects(result, data)
+	}
+	provider.data = result
+	return result, nil
+}
+
+// Unloaded data is kept by provider and returned by Data
+func (provider *LayeredDataProvider) Unload(data map[string]interface{}) error {
+	provider.data = data
+	return nil
+}
+
+func (provider *LayeredDataProvider) Data() interface{} {
+	return provider.data
+}
+
+// Merge objects without changing of arguments
+func (provider *LayeredDataProvider) mergeObjects(lower, upper map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(lower)+len(upper))
+	for key, value := range lower {
+		result[key] = value
+	}
+	for key, value := range upper {
+		if current, ok := result[key]; ok {
+			result[key] = provider.merge(current, value)
+		} else {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// Merge values of lower and upper layers
+func (provider *LayeredDataProvider) merge(lower, upper interface{}) interface{} {
+	switch upperValue := upper.(type) {
+	case map[string]interface{}:
+		if lowerValue, ok := lower.(map[string]interface{}); ok && provider.objectStrategy == DeepMergeObjects {
+			return provider.mergeObjects(lowerValue, upperValue)
+		}
+	case []interface{}:
+		lowerValue, ok := lower.([]interface{})
+		if !ok {
+			break
+		}
+		switch provider.sliceStrategy {
+		case AppendSlices:
+			result := make([]interface{}, 0, len(lowerValue)+len(upperValue))
+			return append(append(result, lowerValue...), upperValue...)
+		case MergeSlicesByKey:
+			return provider.mergeSlicesByKey(lowerValue, upperValue)
+		}
+	}
+	return upper
+}
+
+// Merge objects of slices with same value of merge key
+func (provider *LayeredDataProvider) mergeSlicesByKey(lower, upper []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(lower)+len(upper))
+	result = append(result, lower...)
+	for _, value := range upper {
+		merged := false
+		if object, ok := value.(map[string]interface{}); ok {
+			if key, ok := object[provider.mergeKey]; ok {
+				for index, current := range result {
+					if currentObject, ok := current.(map[string]interface{}); ok &&
+						reflect.DeepEqual(currentObject[provider.mergeKey], key) {
+						result[index] = provider.merge(currentObject, object)
+						merged = true
+						break
+					}
+				}
+			}
+		}
+		if !merged {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package providers
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func loadLayers(t *testing.T, provider *LayeredDataProvider) string {
+	data, err := provider.Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	result, _ := json.Marshal(data)
+	return string(result)
+}
+
+func TestLayeredLoad(t *testing.T) {
+	defaults := NewJsonDataProvider([]byte(`{"host":"localhost","port":80,"server":{"name":"default","debug":false},
+		"params":[{"name":"a","value":1}]}`))
+	file := NewJsonDataProvider([]byte(`{"port":8080,"server":{"name":"file"},"params":[{"name":"a","value":2},{"name":"b"}]}`))
+	env := NewJsonDataProvider([]byte(`{"server":{"debug":true}}`))
+
+	tests := []struct {
+		provider *LayeredDataProvider
+		expected string
+	}{
+		{
+			provider: NewLayeredDataProvider(defaults, file, env),
+			expected: `{"host":"localhost","params":[{"name":"a","value":2},{"name":"b"}],"port":8080,` +
+				`"server":{"debug":true,"name":"file"}}`,
+		},
+		{
+			provider: NewLayeredDataProvider(defaults, file, env).SetSliceStrategy(AppendSlices, ""),
+			expected: `{"host":"localhost","params":[{"name":"a","value":1},{"name":"a","value":2},{"name":"b"}],` +
+				`"port":8080,"server":{"debug":true,"name":"file"}}`,
+		},
+		{
+			provider: NewLayeredDataProvider(defaults, file, env).SetSliceStrategy(MergeSlicesByKey, "name"),
+			expected: `{"host":"localhost","params":[{"name":"a","value":2},{"name":"b"}],"port":8080,` +
+				`"server":{"debug":true,"name":"file"}}`,
+		},
+		{
+			provider: NewLayeredDataProvider(defaults, file, env).SetObjectStrategy(ReplaceObjects),
+			expected: `{"host":"localhost","params":[{"name":"a","value":2},{"name":"b"}],"port":8080,` +
+				`"server":{"debug":true}}`,
+		},
+	}
+	for _, test := range tests {
+		if result := loadLayers(t, test.provider); result != test.expected {
+			t.Errorf("expected: %s, actual: %s", test.expected, result)
+		}
+	}
+}
+
+func TestLayeredMergeByKey(t *testing.T) {
+	provider := NewLayeredDataProvider(
+		NewJsonDataProvider([]byte(`{"params":[{"name":"a","value":1,"label":"x"},"raw"]}`)),
+		NewJsonDataProvider([]byte(`{"params":[{"name":"a","value":2},{"value":3}]}`)),
+	).SetSliceStrategy(MergeSlicesByKey, "name")
+	expected := `{"params":[{"label":"x","name":"a","value":2},"raw",{"value":3}]}`
+	if result := loadLayers(t, provider); result != expected {
+		t.Errorf("expected: %s, actual: %s", expected, result)
+	}
+}
+
+// Provider with constant data
+type mapDataProvider map[string]interface{}
+
+func (provider mapDataProvider) Load() (map[string]interface{}, error) { return provider, nil }
+func (provider mapDataProvider) Unload(map[string]interface{}) error   { return nil }
+func (provider mapDataProvider) Data() interface{}                     { return provider }
+
+func TestLayeredDoesNotChangeLayers(t *testing.T) {
+	lower := mapDataProvider{"server": map[string]interface{}{"name": "lower"}}
+	upper := mapDataProvider{"server": map[string]interface{}{"port": 1}}
+	provider := NewLayeredDataProvider(lower, upper)
+	loadLayers(t, provider)
+	if len(lower["server"].(map[string]interface{})) != 1 {
+		t.Error("data of lower layer is changed")
+	}
+}
+
+func TestLayeredLoadError(t *testing.T) {
+	provider := NewLayeredDataProvider(NewJsonDataProvider([]byte(`{}`)), NewJsonDataProvider(nil))
+	if _, err := provider.Load(); err == nil {
+		t.Error("there must be an error of second layer")
+	}
+}
+
+func TestLayeredUnload(t *testing.T) {
+	provider := NewLayeredDataProvider()
+	raw := map[string]interface{}{"host": "string required"}
+	if err := provider.Unload(raw); err != nil {
+		t.Error(err)
+	}
+	if data, ok := provider.Data().(map[string]interface{}); !ok || data["host"] != "string required" {
+		t.Errorf("invalid data: %v", provider.Data())
+	}
+}
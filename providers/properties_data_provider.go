@@ -0,0 +1,152 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Provider of Java properties files, dotted keys are nested objects (e.g. server.params.0.label),
+// values are parsed according to schema of reflector
+type PropertiesDataProvider struct {
+	data   []byte
+	schema *Schema
+}
+
+func NewPropertiesDataProvider(data []byte) *PropertiesDataProvider {
+	return &PropertiesDataProvider{
+		data: data,
+	}
+}
+
+// Set schema of reflector
+func (provider *PropertiesDataProvider) SetSchema(schema *Schema) {
+	provider.schema = schema
+}
+
+func (provider *PropertiesDataProvider) Load() (map[string]interface{}, error) {
+	tree := map[string]interface{}{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(provider.data))
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		// join continuation lines
+		start := number
+		for isContinued(line) && scanner.Scan() {
+			number++
+			line = line[:len(line)-1] + strings.TrimLeft(scanner.Text(), " \t\f")
+		}
+		key, value := splitProperty(line)
+		key, err := unescapeProperty(key)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("line %d: %s", start, err))
+		}
+		if value, err = unescapeProperty(value); err != nil {
+			return nil, errors.New(fmt.Sprintf("line %d: %s", start, err))
+		}
+		if err := setTreeValue(tree, splitKey(key), value); err != nil {
+			return nil, errors.New(fmt.Sprintf("line %d: %s", start, err))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result, err := typedTree(tree, provider.schema, "")
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]interface{}), nil
+}
+
+// Unload commented template with dotted keys
+func (provider *PropertiesDataProvider) Unload(data map[string]interface{}) error {
+	lines := map[string]string{}
+	documentTemplate(lines, "", data, dottedName)
+
+	var buffer bytes.Buffer
+	for _, name := range sortedKeys(lines) {
+		if lines[name] != "" {
+			buffer.WriteString("# " + lines[name] + "\n")
+		}
+		buffer.WriteString(name + "=\n")
+	}
+	provider.data = buffer.Bytes()
+	return nil
+}
+
+func (provider *PropertiesDataProvider) Data() interface{} {
+	return provider.data
+}
+
+// Line is continued if it ends with odd number of backslashes
+func isContinued(line string) bool {
+	count := 0
+	for index := len(line) - 1; index >= 0 && line[index] == '\\'; index-- {
+		count++
+	}
+	return count%2 == 1
+}
+
+// Split line to key and value separated by `=`, `:` or white space
+func splitProperty(line string) (string, string) {
+	for index := 0; index < len(line); index++ {
+		switch line[index] {
+		case '\\':
+			index++ // skip escaped char
+		case '=', ':':
+			return line[:index], strings.TrimLeft(line[index+1:], " \t\f")
+		case ' ', '\t', '\f':
+			value := strings.TrimLeft(line[index:], " \t\f")
+			if value != "" && (value[0] == '=' || value[0] == ':') {
+				value = strings.TrimLeft(value[1:], " \t\f")
+			}
+			return line[:index], value
+		}
+	}
+	return line, ""
+}
+
+// Replace escape sequences
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+	var buffer strings.Builder
+	for index := 0; index < len(s); index++ {
+		if s[index] != '\\' || index == len(s)-1 {
+			buffer.WriteByte(s[index])
+			continue
+		}
+		index++
+		switch s[index] {
+		case 't':
+			buffer.WriteByte('\t')
+		case 'n':
+			buffer.WriteByte('\n')
+		case 'r':
+			buffer.WriteByte('\r')
+		case 'f':
+			buffer.WriteByte('\f')
+		case 'u':
+			if index+4 >= len(s) {
+				return "", errors.New("invalid unicode escape sequence")
+			}
+			code, err := strconv.ParseUint(s[index+1:index+5], 16, 16)
+			if err != nil {
+				return "", errors.New(fmt.Sprintf("invalid unicode escape sequence `\\u%s`", s[index+1:index+5]))
+			}
+			buffer.WriteRune(rune(code))
+			index += 4
+		default:
+			buffer.WriteByte(s[index])
+		}
+	}
+	return buffer.String(), nil
+}
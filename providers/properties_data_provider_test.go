@@ -0,0 +1,77 @@
+package providers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPropertiesLoad(t *testing.T) {
+	raw := []byte(`
+# comment
+! another comment
+host=localhost
+port : 8081
+debug true
+tags = a,\
+       b
+server.name = main\tserver
+server.labels.env = prod
+server.params.0.label = first
+server.params.0.weight = 0.5
+server.params.1.label = second
+key\ with\ spaces = value
+`)
+	provider := NewPropertiesDataProvider(raw)
+	provider.SetSchema(iniSchema)
+	result, err := provider.Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"host":  "localhost",
+		"port":  int64(8081),
+		"debug": true,
+		"tags":  []interface{}{"a", "b"},
+		"server": map[string]interface{}{
+			"name":   "main\tserver",
+			"labels": map[string]interface{}{"env": "prod"},
+			"params": []interface{}{
+				map[string]interface{}{"label": "first", "weight": 0.5},
+				map[string]interface{}{"label": "second"},
+			},
+		},
+		"key with spaces": "value",
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, result)
+	}
+}
+
+func TestPropertiesInvalidFormat(t *testing.T) {
+	tests := []string{
+		"port = 80s",
+		"host = \\u00",
+		"server = main\nserver.name = main",
+	}
+	for _, test := range tests {
+		provider := NewPropertiesDataProvider([]byte(test))
+		provider.SetSchema(iniSchema)
+		if _, err := provider.Load(); err == nil {
+			t.Errorf("there must be an error for %q", test)
+		}
+	}
+}
+
+func TestPropertiesUnload(t *testing.T) {
+	provider := NewPropertiesDataProvider(nil)
+	if err := provider.Unload(map[string]interface{}{
+		"host":   "string required",
+		"server": map[string]interface{}{"params": []interface{}{map[string]interface{}{"label": "string"}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "# string required\nhost=\n# string\nserver.params.{index}.label=\n"
+	if string(provider.Data().([]byte)) != expected {
+		t.Errorf("unexpected value:\n%s", provider.Data())
+	}
+}
@@ -0,0 +1,201 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Placeholder of slice index in documentation
+const indexPlaceholder = "{index}"
+
+// Kinds of values in Schema, the same as kinds of reflector.FieldInfo
+const (
+	IntKind      = "int"
+	UintKind     = "uint"
+	FloatKind    = "float"
+	StringKind   = "string"
+	BoolKind     = "bool"
+	DatetimeKind = "datetime"
+	ObjectKind   = "object"
+	ArrayKind    = "array"
+	MapKind      = "map"
+)
+
+// Schema of value built by reflector from its fields, providers use it to parse raw strings
+type Schema struct {
+	Kind   string             // kind of value e.g. int or object
+	Fields map[string]*Schema // schemas of object fields by config names
+	Elem   *Schema            // schema of array elements and map values
+}
+
+// Get schema of object field, nil if schema is not an object or does not have field
+func (schema *Schema) field(name string) *Schema {
+	if schema == nil || schema.Kind != ObjectKind {
+		return nil
+	}
+	return schema.Fields[name]
+}
+
+// Parse string according to kind of schema, arrays of scalars are separated by comma
+func parseValue(raw string, schema *Schema) (interface{}, error) {
+	if schema == nil {
+		return raw, nil
+	}
+	switch schema.Kind {
+	case ArrayKind:
+		result := []interface{}{}
+		if strings.TrimSpace(raw) == "" {
+			return result, nil
+		}
+		for _, item := range strings.Split(raw, ",") {
+			value, err := parseValue(strings.TrimSpace(item), schema.Elem)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+		}
+		return result, nil
+	case IntKind:
+		return strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	case UintKind:
+		return strconv.ParseUint(strings.TrimSpace(raw), 10, 64)
+	case FloatKind:
+		return strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	case BoolKind:
+		return parseBool(raw)
+	}
+	return raw, nil
+}
+
+// Parse boolean value, accepts true/false, yes/no, on/off, y/n and 1/0
+func parseBool(raw string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "true", "yes", "on", "y", "1":
+		return true, nil
+	case "false", "no", "off", "n", "0":
+		return false, nil
+	}
+	return false, errors.New(fmt.Sprintf("invalid boolean value `%s`", raw))
+}
+
+// Collect documentation of scalar fields from data unloaded by reflector template
+// by names built with join function, slices of structs use index placeholder in names
+func documentTemplate(lines map[string]string, name string, template interface{}, join func(string, string) string) {
+	switch template := template.(type) {
+	case map[string]interface{}:
+		for key, value := range template {
+			documentTemplate(lines, join(name, key), value, join)
+		}
+	case []interface{}:
+		if len(template) == 0 {
+			lines[name] = ""
+		} else if _, ok := template[0].(map[string]interface{}); ok {
+			documentTemplate(lines, join(name, indexPlaceholder), template[0], join)
+		} else {
+			lines[name] = fmt.Sprintf("comma separated list of %v", template[0])
+		}
+	default:
+		lines[name] = fmt.Sprintf("%v", template)
+	}
+}
+
+// Join names by dot
+func dottedName(name string, key string) string {
+	if name == "" {
+		return key
+	}
+	return name + "." + key
+}
+
+// Sorted keys of map
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Set raw value in nested objects by path of keys
+func setTreeValue(tree map[string]interface{}, path []string, raw string) error {
+	for index, key := range path[:len(path)-1] {
+		switch node := tree[key].(type) {
+		case nil:
+			child := map[string]interface{}{}
+			tree[key] = child
+			tree = child
+		case map[string]interface{}:
+			tree = node
+		default:
+			return errors.New(fmt.Sprintf("key `%s` has value and can not have nested keys",
+				strings.Join(path[:index+1], ".")))
+		}
+	}
+	key := path[len(path)-1]
+	if _, ok := tree[key].(map[string]interface{}); ok {
+		return errors.New(fmt.Sprintf("key `%s` has nested keys and can not have value", strings.Join(path, ".")))
+	}
+	tree[key] = raw
+	return nil
+}
+
+// Convert tree of raw strings to values according to schema, objects with
+// numeric keys become slices of structs, without schema values stay strings
+func typedTree(value interface{}, schema *Schema, path string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if schema != nil && schema.Kind == ArrayKind {
+			return typedSlice(v, schema.Elem, path)
+		}
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			itemSchema := schema.field(key)
+			if schema != nil && schema.Kind == MapKind {
+				itemSchema = schema.Elem
+			}
+			typed, err := typedTree(item, itemSchema, dottedName(path, key))
+			if err != nil {
+				return nil, err
+			}
+			result[key] = typed
+		}
+		return result, nil
+	case string:
+		typed, err := parseValue(v, schema)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("invalid value of `%s`: %s", path, err))
+		}
+		return typed, nil
+	}
+	return value, nil
+}
+
+// Convert object with numeric keys to slice ordered by keys
+func typedSlice(object map[string]interface{}, schema *Schema, path string) ([]interface{}, error) {
+	keys := make([]string, 0, len(object))
+	indexes := make(map[string]int, len(object))
+	for key := range object {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 {
+			return nil, errors.New(fmt.Sprintf("invalid index `%s` of `%s`", key, path))
+		}
+		keys = append(keys, key)
+		indexes[key] = index
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return indexes[keys[i]] < indexes[keys[j]]
+	})
+	result := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		typed, err := typedTree(object[key], schema, dottedName(path, key))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, typed)
+	}
+	return result, nil
+}
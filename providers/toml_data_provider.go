@@ -0,0 +1,62 @@
+package providers
+
+import (
+	"bytes"
+
+	"github.com/BurntSushi/toml"
+)
+
+type TomlDataProvider struct {
+	data []byte
+}
+
+func NewTomlDataProvider(data []byte) *TomlDataProvider {
+	return &TomlDataProvider{
+		data: data,
+	}
+}
+
+// Load tables as objects and arrays of tables as slices of objects, datetime values are time.Time
+func (provider *TomlDataProvider) Load() (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	if _, err := toml.Decode(string(provider.data), &result); err != nil {
+		return nil, err
+	}
+	return normalizeToml(result).(map[string]interface{}), nil
+}
+
+func (provider *TomlDataProvider) Unload(data map[string]interface{}) error {
+	var buffer bytes.Buffer
+	if err := toml.NewEncoder(&buffer).Encode(data); err != nil {
+		return err
+	}
+	provider.data = buffer.Bytes()
+	return nil
+}
+
+func (provider *TomlDataProvider) Data() interface{} {
+	return provider.data
+}
+
+// Convert arrays of tables to slices of objects
+func normalizeToml(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeToml(item)
+		}
+		return v
+	case []map[string]interface{}:
+		result := make([]interface{}, len(v))
+		for index, item := range v {
+			result[index] = normalizeToml(item)
+		}
+		return result
+	case []interface{}:
+		for index, item := range v {
+			v[index] = normalizeToml(item)
+		}
+		return v
+	}
+	return value
+}
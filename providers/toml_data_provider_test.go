@@ -0,0 +1,80 @@
+package providers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTomlLoad(t *testing.T) {
+	raw := []byte(`
+host = "localhost"
+port = 8080
+ratio = 0.5
+started = 2020-01-02T03:04:05Z
+labels = { env = "prod", tier = "web" }
+ports = [80, 443]
+
+[server]
+name = "main"
+
+[[server.params]]
+label = "first"
+
+[[server.params]]
+label = "second"
+`)
+	provider := NewTomlDataProvider(raw)
+	result, err := provider.Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"host":    "localhost",
+		"port":    int64(8080),
+		"ratio":   0.5,
+		"started": time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		"labels":  map[string]interface{}{"env": "prod", "tier": "web"},
+		"ports":   []interface{}{int64(80), int64(443)},
+		"server": map[string]interface{}{
+			"name": "main",
+			"params": []interface{}{
+				map[string]interface{}{"label": "first"},
+				map[string]interface{}{"label": "second"},
+			},
+		},
+	}
+	if started, ok := result["started"].(time.Time); !ok || !started.Equal(expected["started"].(time.Time)) {
+		t.Errorf("invalid datetime value: %v", result["started"])
+	}
+	result["started"] = expected["started"]
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, result)
+	}
+}
+
+func TestTomlInvalidFormat(t *testing.T) {
+	if _, err := NewTomlDataProvider([]byte(`host = `)).Load(); err == nil {
+		t.Error("there must be an error for invalid format")
+	}
+}
+
+func TestTomlUnload(t *testing.T) {
+	provider := NewTomlDataProvider(nil)
+	raw := map[string]interface{}{
+		"host": "string required",
+		"server": map[string]interface{}{
+			"name":   "string",
+			"params": []interface{}{map[string]interface{}{"label": "string"}},
+		},
+	}
+	if err := provider.Unload(raw); err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	t.Log(string(provider.Data().([]byte)))
+	if result, err := NewTomlDataProvider(provider.Data().([]byte)).Load(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(result, raw) {
+		t.Errorf("expected: %v, actual: %v", raw, result)
+	}
+}
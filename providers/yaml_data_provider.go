@@ -0,0 +1,84 @@
+package providers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Provider of YAML 1.2 documents, keys like on, y or no are strings and not booleans
+type YamlDataProvider struct {
+	data []byte
+}
+
+func NewYamlDataProvider(data []byte) *YamlDataProvider {
+	return &YamlDataProvider{
+		data: data,
+	}
+}
+
+func (provider *YamlDataProvider) Load() (map[string]interface{}, error) {
+	var result interface{}
+	if err := yaml.Unmarshal(provider.data, &result); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		// empty document
+		return map[string]interface{}{}, nil
+	}
+	if v, ok := normalizeYaml(result).(map[string]interface{}); !ok {
+		return nil, errors.New(fmt.Sprintf("yaml document must be a mapping, got %T", result))
+	} else {
+		return v, nil
+	}
+}
+
+// Unload data as yaml indented by two spaces, keys of mappings are sorted
+func (provider *YamlDataProvider) Unload(data map[string]interface{}) error {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(data); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	provider.data = buffer.Bytes()
+	return nil
+}
+
+func (provider *YamlDataProvider) Data() interface{} {
+	return provider.data
+}
+
+// Convert decoded yaml to string keys and int64/float64 numbers
+func normalizeYaml(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[fmt.Sprintf("%v", key)] = normalizeYaml(item)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = normalizeYaml(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for index, item := range v {
+			result[index] = normalizeYaml(item)
+		}
+		return result
+	case int:
+		return int64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
@@ -0,0 +1,104 @@
+package providers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestYamlLoad(t *testing.T) {
+	raw := []byte(`
+host: localhost
+port: 8080
+ratio: 0.5
+debug: true
+server:
+  name: main
+  params:
+    - label: first
+    - label: second
+labels:
+  1: one
+`)
+	provider := NewYamlDataProvider(raw)
+	result, err := provider.Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"host":  "localhost",
+		"port":  int64(8080),
+		"ratio": 0.5,
+		"debug": true,
+		"server": map[string]interface{}{
+			"name": "main",
+			"params": []interface{}{
+				map[string]interface{}{"label": "first"},
+				map[string]interface{}{"label": "second"},
+			},
+		},
+		"labels": map[string]interface{}{"1": "one"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, result)
+	}
+}
+
+func TestYamlBooleanLikeKeys(t *testing.T) {
+	raw := []byte(`
+n: 1
+y: 2
+on: true
+off: no
+yes: 'yes'
+`)
+	result, err := NewYamlDataProvider(raw).Load()
+	if err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	expected := map[string]interface{}{
+		"n":   int64(1),
+		"y":   int64(2),
+		"on":  true,
+		"off": "no",
+		"yes": "yes",
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, result)
+	}
+}
+
+func TestYamlInvalidFormat(t *testing.T) {
+	for _, raw := range []string{"- one\n- two\n", "host: [localhost"} {
+		if _, err := NewYamlDataProvider([]byte(raw)).Load(); err == nil {
+			t.Errorf("there must be an error for %q", raw)
+		}
+	}
+	if result, err := NewYamlDataProvider(nil).Load(); err != nil || len(result) != 0 {
+		t.Error("empty document has no values")
+	}
+}
+
+func TestYamlUnload(t *testing.T) {
+	provider := NewYamlDataProvider(nil)
+	raw := map[string]interface{}{
+		"port": "int default 8080",
+		"host": "string required",
+		"server": map[string]interface{}{
+			"params": []interface{}{map[string]interface{}{"label": "string"}},
+			"name":   "string",
+		},
+	}
+	if err := provider.Unload(raw); err != nil {
+		t.Fatalf("there can not be an error: %s", err)
+	}
+	expected := `host: string required
+port: int default 8080
+server:
+  name: string
+  params:
+    - label: string
+`
+	if string(provider.Data().([]byte)) != expected {
+		t.Errorf("unexpected value: %s", provider.Data())
+	}
+}
@@ -3,12 +3,17 @@ package reflector
 import (
 	"reflect"
 	"errors"
+	"fmt"
+
+	"stash.abc.ee/micro/reflector/providers"
 )
 
 type Reflector struct  {
 	source interface{}
 	tagName string
 	fields []reflectionField
+	keyRing *KeyRing
+	resolvers map[string]Resolver
 }
 
 // Data provider interface
@@ -18,7 +23,13 @@ type DataProvider interface {
 	Data() interface{}
 }
 
-// Create new reflector
+// Data provider which needs schema of reflection source for loading e.g. to parse raw strings
+type SchemaDataProvider interface {
+	DataProvider
+	SetSchema(schema *providers.Schema)
+}
+
+// Create new reflector, problems of struct tags are returned as SchemaErrors
 func New(source interface{}, tagName string) (*Reflector, error) {
 	// Check if source is pointer
 	if reflect.TypeOf(source).Kind() != reflect.Ptr {
@@ -32,48 +43,92 @@ func New(source interface{}, tagName string) (*Reflector, error) {
 	if tagName == "" {
 		return nil, errors.New("tagName can not be an empty")
 	}
-	fields := processingTags(reflect.TypeOf(source).Elem(), tagName)
-	if len(fields) == 0 {
+	compiled := compileSchema(reflect.TypeOf(source).Elem(), tagName)
+	if len(compiled.errors) > 0 {
+		return nil, compiled.errors
+	}
+	if len(compiled.fields) == 0 {
 		return nil, errors.New("source does not have configuration tags")
 	}
 
 	return &Reflector{
 		source: source,
 		tagName: tagName,
-		fields: fields,
+		fields: compiled.fields,
 	}, nil
 }
 
-// Get template for reflection source
+// Get template for reflection source, template is rendered from Schema
 func (reflection *Reflector) Template(provider DataProvider) (interface{}, error) {
 
-	raw := map[string]interface{}{}
-	for _, field := range reflection.fields {
-		raw[field.configField.Name] = field.GetInfo()
-	}
-
-	if err := provider.Unload(raw); err != nil {
+	if err := provider.Unload(reflection.templateData()); err != nil {
 		return nil, err
 	}
 
 	return provider.Data(), nil
 }
 
-// Set values and return
+// Set values and return, references between fields like ${server.host} are interpolated
+// and $${...} is kept as ${...}, all invalid values are reported as ValidationErrors
 func (reflection *Reflector) SetValues(provider DataProvider) (interface{}, error){
+	if schemaProvider, ok := provider.(SchemaDataProvider); ok {
+		schemaProvider.SetSchema(reflection.providerSchema())
+	}
 	// get data from provider
 	data, err := provider.Load()
 	if err != nil {
 		return nil, err
 	}
 	valueOf := reflect.ValueOf(reflection.source)
-	if err := setFieldsValues(&valueOf, reflection.fields, data); err != nil {
-		return nil, err
+	loader := valueLoader{keyRing: reflection.keyRing, resolvers: reflection.resolvers}
+	// encrypted values and references between fields are resolved in data merged with defaults
+	data = loader.interpolate(reflection.fields, data)
+	loader.setFieldsValues(&valueOf, reflection.fields, data, "")
+	if len(loader.errors) > 0 {
+		return nil, loader.errors
 	}
 
-
 	return reflection.source, nil
 }
 
+// Set keys for decryption of encrypted values
+func (reflection *Reflector) SetKeyRing(keyRing *KeyRing) {
+	reflection.keyRing = keyRing
+}
+
+// Set resolver for references with scheme only for this reflector, nil resolver disables scheme
+func (reflection *Reflector) SetResolver(scheme string, resolver Resolver) {
+	if reflection.resolvers == nil {
+		reflection.resolvers = map[string]Resolver{}
+	}
+	reflection.resolvers[scheme] = resolver
+}
+
+// Encrypt value for field with path e.g. server.password or labels.token
+func (reflection *Reflector) Encrypt(path string, value string) (string, error) {
+	if reflection.keyRing == nil {
+		return "", errors.New("key ring is not set")
+	}
+	field, _, err := lookupPath(reflection.fields, nil, path)
+	if err != nil {
+		return "", err
+	}
+	if !isScalarType(field.fieldType) && field.fieldType != timeType {
+		return "", errors.New(fmt.Sprintf("field `%s` does not have scalar value", path))
+	}
+	return reflection.keyRing.Encrypt(path, value)
+}
 
+// Information about fields by config names
+func (reflection *Reflector) templateData() map[string]interface{} {
+	raw := map[string]interface{}{}
+	for _, info := range reflection.Schema() {
+		raw[info.Name] = info.Template()
+	}
+	return raw
+}
 
+// Schema of reflection source for providers
+func (reflection *Reflector) providerSchema() *providers.Schema {
+	return &providers.Schema{Kind: ObjectKind, Fields: providerSchemas(reflection.Schema())}
+}
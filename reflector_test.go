@@ -1,7 +1,9 @@
 package reflector
 
 import (
+	"strings"
 	"testing"
+	"time"
 	"stash.abc.ee/micro/reflector/providers"
 )
 
@@ -186,3 +188,300 @@ func TestSliceValue(t *testing.T) {
 
 
 
+
+func TestPointerValues(t *testing.T) {
+	type Server struct {
+		Name string `config:"name"`
+	}
+	type Config struct {
+		Percent *float64  `config:"percent"`
+		Timeout *int64    `config:"timeout has_default 30"`
+		Host    *string   `config:"host"`
+		Server  *Server   `config:"server"`
+		Backup  *Server   `config:"backup"`
+		Params  *[]string `config:"params"`
+		Nodes   []*Server `config:"nodes"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider([]byte(`{"percent":0,"host":null,"server":{"name":"main"},
+		"params":["one"],"nodes":[{"name":"first"}]}`))
+	if config, err := r.SetValues(provider); err != nil {
+		t.Error(err)
+	} else {
+		config := config.(*Config)
+		if config.Percent == nil || *config.Percent != 0 {
+			t.Error("percent must be allocated with zero value")
+		}
+		if config.Timeout == nil || *config.Timeout != 30 {
+			t.Error("timeout must be allocated with default value")
+		}
+		if config.Host != nil {
+			t.Error("host must be nil")
+		}
+		if config.Server == nil || config.Server.Name != "main" {
+			t.Errorf("invalid value for server: %v", config.Server)
+		}
+		if config.Backup != nil {
+			t.Error("backup must be nil")
+		}
+		if config.Params == nil || len(*config.Params) != 1 {
+			t.Errorf("invalid value for params: %v", config.Params)
+		}
+		if len(config.Nodes) != 1 || config.Nodes[0].Name != "first" {
+			t.Errorf("invalid value for nodes: %v", config.Nodes)
+		}
+	}
+}
+
+func TestRequiredPointerNull(t *testing.T) {
+	type Config struct {
+		Port   *int    `config:"port is_required"`
+		TLS    bool    `config:"tls"`
+		Cert   *string `config:"cert is_required_if tls"`
+		Backup *int    `config:"backup"`
+	}
+	config := &Config{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = r.SetValues(providers.NewJsonDataProvider([]byte(`{"port":null,"tls":true,"cert":null,"backup":null}`)))
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("invalid errors: %v", err)
+	}
+	if errs[0].Path != "port" || errs[0].Code != RequiredCode || errs[1].Path != "cert" ||
+		errs[1].Code != RequiredCode {
+		t.Errorf("null of required pointer must be reported: %s", errs)
+	}
+
+	if _, err := r.SetValues(providers.NewJsonDataProvider([]byte(`{"port":0,"backup":null}`))); err != nil {
+		t.Error(err)
+	} else if config.Port == nil || *config.Port != 0 || config.Backup != nil {
+		t.Errorf("invalid values: %+v", config)
+	}
+}
+
+func TestPointerTemplate(t *testing.T) {
+	config := &struct {
+		Port *int `config:"port"`
+	}{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider(nil)
+	if template, err := r.Template(provider); err != nil {
+		t.Error(err)
+	} else if string(template.([]byte)) != `{"port":"int optional"}` {
+		t.Errorf("invalid template: %s", template)
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	type Backend struct {
+		Host   string `config:"host is_required"`
+		Weight float64 `config:"weight has_default 1.0"`
+	}
+	type Config struct {
+		Labels   map[string]string    `config:"labels"`
+		Groups   map[string][]string  `config:"groups"`
+		Backends map[string]Backend   `config:"backends"`
+		Extra    map[string]*Backend  `config:"extra"`
+		Empty    map[string]string    `config:"empty"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider([]byte(`{"labels":{"env":"prod"},"groups":{"admins":["root"]},
+		"backends":{"first":{"host":"10.0.0.1"}},"extra":{"second":{"host":"10.0.0.2","weight":2.5}}}`))
+	if config, err := r.SetValues(provider); err != nil {
+		t.Error(err)
+	} else {
+		config := config.(*Config)
+		if config.Labels["env"] != "prod" {
+			t.Errorf("invalid value for labels: %v", config.Labels)
+		}
+		if len(config.Groups["admins"]) != 1 || config.Groups["admins"][0] != "root" {
+			t.Errorf("invalid value for groups: %v", config.Groups)
+		}
+		if b := config.Backends["first"]; b.Host != "10.0.0.1" || b.Weight != 1.0 {
+			t.Errorf("invalid value for backends: %v", config.Backends)
+		}
+		if b := config.Extra["second"]; b == nil || b.Weight != 2.5 {
+			t.Errorf("invalid value for extra: %v", config.Extra)
+		}
+		if config.Empty != nil {
+			t.Error("empty map must be nil")
+		}
+	}
+
+	// nested tags of map values are processed
+	provider = providers.NewJsonDataProvider([]byte(`{"backends":{"first":{"weight":2.0}}}`))
+	if _, err := r.SetValues(provider); err == nil {
+		t.Error("there must be an error for required host")
+	}
+}
+
+func TestMapTemplate(t *testing.T) {
+	config := &struct {
+		Labels   map[string]string `config:"labels"`
+		Backends map[string]struct {
+			Host string `config:"host is_required"`
+		} `config:"backends"`
+	}{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider(nil)
+	expected := `{"backends":{"{key}":{"host":"string required"}},"labels":{"{key}":"string"}}`
+	if template, err := r.Template(provider); err != nil {
+		t.Error(err)
+	} else if string(template.([]byte)) != expected {
+		t.Errorf("invalid template: %s", template)
+	}
+}
+
+func TestDefaultSliceValue(t *testing.T) {
+	type Config struct {
+		Params []string `config:"params has_default ['one', 'two']"`
+		Server struct {
+			Name string `config:"name has_default 'local'"`
+		} `config:"server"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config, err := r.SetValues(providers.NewJsonDataProvider([]byte(`{}`))); err != nil {
+		t.Error(err)
+	} else {
+		config := config.(*Config)
+		if len(config.Params) != 2 || config.Params[1] != "two" {
+			t.Errorf("invalid value for params: %v", config.Params)
+		}
+		if config.Server.Name != "local" {
+			t.Errorf("invalid value for server name: %s", config.Server.Name)
+		}
+	}
+}
+
+func TestSchemaErrorsFromNew(t *testing.T) {
+	type Config struct {
+		Name string `config:"name is_required_if"`
+	}
+	if _, err := New(&Config{}, "config"); err == nil {
+		t.Error("there must be an error")
+	} else if _, ok := err.(SchemaErrors); !ok {
+		t.Errorf("invalid type of error: %T", err)
+	}
+}
+
+func TestSliceTemplate(t *testing.T) {
+	config := &struct {
+		Tags   []string `config:"tags"`
+		Debug  bool     `config:"debug"`
+		Params []struct {
+			Label string `config:"label is_required"`
+		} `config:"params"`
+	}{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewJsonDataProvider(nil)
+	expected := `{"debug":"bool","params":[{"label":"string required"}],"tags":["string"]}`
+	if template, err := r.Template(provider); err != nil {
+		t.Error(err)
+	} else if string(template.([]byte)) != expected {
+		t.Errorf("invalid template: %s", template)
+	}
+}
+
+func TestSetValuesWithSchemaProvider(t *testing.T) {
+	type Config struct {
+		Port   uint16 `config:"port"`
+		Server struct {
+			Params []struct {
+				Label string `config:"label"`
+			} `config:"params"`
+		} `config:"server"`
+	}
+	t.Setenv("TEST_REFLECTOR_PORT", "8080")
+	t.Setenv("TEST_REFLECTOR_SERVER_PARAMS_0_LABEL", "first")
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config, err := r.SetValues(providers.NewEnvDataProvider("TEST_REFLECTOR")); err != nil {
+		t.Error(err)
+	} else {
+		config := config.(*Config)
+		if config.Port != 8080 || len(config.Server.Params) != 1 || config.Server.Params[0].Label != "first" {
+			t.Errorf("invalid values: %+v", config)
+		}
+	}
+}
+
+func TestTomlValues(t *testing.T) {
+	type Config struct {
+		Started  time.Time   `config:"started is_required"`
+		Deadline *time.Time  `config:"deadline"`
+		Stopped  time.Time   `config:"stopped has_default '2020-01-02T00:00:00Z'"`
+		Server   struct {
+			Name   string `config:"name"`
+			Params []struct {
+				Label string `config:"label is_required"`
+				Port  uint16 `config:"port"`
+			} `config:"params"`
+		} `config:"server"`
+		Labels map[string]string `config:"labels"`
+	}
+	r, err := New(&Config{}, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := providers.NewTomlDataProvider([]byte(`
+started = 2020-01-01T10:00:00Z
+labels = { env = "prod" }
+
+[server]
+name = "main"
+
+[[server.params]]
+label = "first"
+port = 8080
+`))
+	if config, err := r.SetValues(provider); err != nil {
+		t.Error(err)
+	} else {
+		config := config.(*Config)
+		if !config.Started.Equal(time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)) || config.Deadline != nil {
+			t.Errorf("invalid datetime values: %+v", config)
+		}
+		if !config.Stopped.Equal(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("invalid default datetime: %s", config.Stopped)
+		}
+		if len(config.Server.Params) != 1 || config.Server.Params[0].Port != 8080 || config.Labels["env"] != "prod" {
+			t.Errorf("invalid values: %+v", config)
+		}
+	}
+
+	if template, err := r.Template(providers.NewTomlDataProvider(nil)); err != nil {
+		t.Error(err)
+	} else if !strings.Contains(string(template.([]byte)), `started = "datetime required"`) {
+		t.Errorf("invalid template: %s", template)
+	}
+
+	// invalid datetime format
+	if _, err := r.SetValues(providers.NewJsonDataProvider([]byte(`{"started":"yesterday"}`))); err == nil {
+		t.Error("there must be an error for invalid datetime")
+	} else if errs := err.(ValidationErrors); errs[0].Code != InvalidFormatCode {
+		t.Errorf("invalid error: %s", errs[0])
+	}
+}
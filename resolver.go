@@ -0,0 +1,139 @@
+package reflector
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Resolver returns value of reference without scheme e.g. HOME for ${env:HOME}
+type Resolver func(reference string) (string, error)
+
+// Only environment variables are resolved by default, FileResolver and ExecResolver
+// must be registered explicitly because values can come from remote providers
+var (
+	resolversMutex sync.RWMutex
+	resolvers      = map[string]Resolver{
+		"env": EnvResolver,
+	}
+)
+
+// References in string values e.g. ${env:HOME} or ${file:/run/secrets/password},
+// escaped $${env:HOME} is replaced by ${env:HOME} without resolving
+var referencePattern = regexp.MustCompile(`(\$?)\$\{([a-z][a-z0-9_-]*):([^}]*)\}`)
+
+// Register resolver for references with scheme for all reflectors, nil resolver removes scheme
+func RegisterResolver(scheme string, resolver Resolver) {
+	resolversMutex.Lock()
+	defer resolversMutex.Unlock()
+	if resolver == nil {
+		delete(resolvers, scheme)
+		return
+	}
+	resolvers[scheme] = resolver
+}
+
+// Get resolver for scheme, resolvers of reflector take precedence over registered ones
+func getResolver(scheme string, local map[string]Resolver) (Resolver, bool) {
+	if resolver, ok := local[scheme]; ok {
+		return resolver, resolver != nil
+	}
+	resolversMutex.RLock()
+	defer resolversMutex.RUnlock()
+	resolver, ok := resolvers[scheme]
+	return resolver, ok
+}
+
+// Check if string contains references
+func hasReferences(s string) bool {
+	return strings.Contains(s, "${") && referencePattern.MatchString(s)
+}
+
+// Replace all references in string, returns failed reference with error
+func resolveReferences(s string, local map[string]Resolver) (string, string, error) {
+	var failed string
+	var resolveErr error
+	result := referencePattern.ReplaceAllStringFunc(s, func(reference string) string {
+		if resolveErr != nil {
+			return reference
+		}
+		match := referencePattern.FindStringSubmatch(reference)
+		if match[1] != "" {
+			return reference[1:]
+		}
+		resolver, ok := getResolver(match[2], local)
+		if !ok {
+			failed, resolveErr = reference, errors.New(fmt.Sprintf("unknown scheme `%s`", match[2]))
+			return reference
+		}
+		value, err := resolver(match[3])
+		if err != nil {
+			failed, resolveErr = reference, err
+			return reference
+		}
+		return value
+	})
+	if resolveErr != nil {
+		return "", failed, resolveErr
+	}
+	return result, "", nil
+}
+
+// Resolve references in string value, non string fields get value parsed by kind of field
+func (loader *valueLoader) resolveValue(field reflectionField, data interface{}, path string) (interface{}, bool) {
+	s, ok := data.(string)
+	if !ok || !hasReferences(s) {
+		return data, true
+	}
+	resolved, reference, err := resolveReferences(s, loader.resolvers)
+	if err != nil {
+		loader.addReferenceError(path, field, reference, err)
+		return nil, false
+	}
+	return stringValue(field.fieldType, resolved), true
+}
+
+// Value parsed by kind of non string scalar field, unparsable value is kept for validation error
+func stringValue(fieldType reflect.Type, s string) interface{} {
+	if isScalarType(fieldType) && fieldType.Kind() != reflect.String {
+		if parsed, err := parseFlagValue(s, fieldType); err == nil {
+			return parsed
+		}
+	}
+	return s
+}
+
+// Value of environment variable, unset variable is an error
+func EnvResolver(name string) (string, error) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, nil
+	}
+	return "", errors.New(fmt.Sprintf("environment variable `%s` is not set", name))
+}
+
+// Content of file without trailing new lines
+func FileResolver(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// Output of command without trailing new lines, command is split by spaces and run without shell
+func ExecResolver(command string) (string, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return "", errors.New("command is empty")
+	}
+	output, err := exec.Command(args[0], args[1:]...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(output), "\r\n"), nil
+}
@@ -0,0 +1,116 @@
+package reflector
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+func TestResolveReferences(t *testing.T) {
+	t.Setenv("REFLECTOR_TEST_HOST", "localhost")
+	file := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(file, []byte("p4ss\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		value     string
+		expected  string
+		reference string
+	}{
+		{"plain", "plain", ""},
+		{"${unknown}", "${unknown}", ""},
+		{"$${env:REFLECTOR_TEST_HOST} ${env:REFLECTOR_TEST_HOST}", "${env:REFLECTOR_TEST_HOST} localhost", ""},
+		{"http://${env:REFLECTOR_TEST_HOST}:8080", "http://localhost:8080", ""},
+		{"${file:" + file + "}", "p4ss", ""},
+		{"${exec:echo hello world}", "hello world", ""},
+		{"${env:REFLECTOR_TEST_MISSING}", "", "${env:REFLECTOR_TEST_MISSING}"},
+		{"${vault:secret}", "", "${vault:secret}"},
+	}
+	// file and exec are enabled only explicitly
+	local := map[string]Resolver{"file": FileResolver, "exec": ExecResolver}
+	for _, testCase := range testCases {
+		value, reference, err := resolveReferences(testCase.value, local)
+		if testCase.reference != "" {
+			if err == nil || reference != testCase.reference {
+				t.Errorf("expected error for reference %s, got %s %v", testCase.reference, reference, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", testCase.value, err)
+		} else if value != testCase.expected {
+			t.Errorf("invalid value for %s: %s", testCase.value, value)
+		}
+	}
+}
+
+func TestDefaultResolvers(t *testing.T) {
+	for _, reference := range []string{"${file:/etc/hostname}", "${exec:id}"} {
+		if _, _, err := resolveReferences(reference, nil); err == nil {
+			t.Errorf("reference %s must not be resolved by default", reference)
+		}
+	}
+
+	RegisterResolver("global", func(reference string) (string, error) {
+		return reference, nil
+	})
+	t.Cleanup(func() {
+		RegisterResolver("global", nil)
+	})
+	if value, _, err := resolveReferences("${global:x}", nil); err != nil || value != "x" {
+		t.Errorf("invalid value of registered resolver: %s %v", value, err)
+	}
+	// scheme can be disabled for reflector
+	if _, _, err := resolveReferences("${global:x}", map[string]Resolver{"global": nil}); err == nil {
+		t.Error("disabled scheme must not be resolved")
+	}
+}
+
+func TestSetResolver(t *testing.T) {
+	type Config struct {
+		Name    string   `config:"name"`
+		Port    uint16   `config:"port"`
+		Debug   *bool    `config:"debug"`
+		Tags    []string `config:"tags"`
+		Default string   `config:"default has_default '${test:default}'"`
+		Secret  string   `config:"secret is_secret"`
+	}
+	config := &Config{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.SetResolver("test", func(reference string) (string, error) {
+		if reference == "fail" {
+			return "", errors.New("failed")
+		}
+		return strings.ToUpper(reference), nil
+	})
+	provider := providers.NewJsonDataProvider([]byte(`{"name":"${test:name}","port":"${test:8080}",
+		"debug":"${test:true}","tags":["${test:a}","b"]}`))
+	if _, err := r.SetValues(provider); err != nil {
+		t.Fatal(err)
+	}
+	if config.Name != "NAME" || config.Port != 8080 || config.Debug == nil || !*config.Debug ||
+		len(config.Tags) != 2 || config.Tags[0] != "A" || config.Default != "DEFAULT" {
+		t.Errorf("invalid values: %+v", config)
+	}
+
+	// errors name field and reference
+	_, err = r.SetValues(providers.NewJsonDataProvider([]byte(`{"secret":"${test:fail}"}`)))
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("invalid errors: %v", err)
+	}
+	if errs[0].Path != "secret" || errs[0].Code != ReferenceCode || errs[0].Reference != "${test:fail}" {
+		t.Errorf("invalid error: %+v", errs[0])
+	}
+	if errs[0].Error() != "can not resolve reference `${test:fail}` for field `secret`: failed" {
+		t.Errorf("invalid message: %s", errs[0])
+	}
+}
@@ -0,0 +1,46 @@
+package reflector
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Replacement of secret values
+const secretMask = "******"
+
+var secretType = reflect.TypeOf(Secret(""))
+
+// String which never reveals value when printed or marshaled,
+// fields of this type are secret without is_secret keyword
+type Secret string
+
+// Value of secret
+func (secret Secret) Value() string {
+	return string(secret)
+}
+
+func (secret Secret) String() string {
+	return secretMask
+}
+
+func (secret Secret) GoString() string {
+	return secretMask
+}
+
+func (secret Secret) MarshalText() ([]byte, error) {
+	return []byte(secretMask), nil
+}
+
+func (secret Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(secretMask)
+}
+
+// Mark fields, their elements and nested fields as secret
+func markSecret(fields []reflectionField) {
+	for index := range fields {
+		for field := &fields[index]; field != nil; field = field.elem {
+			field.isSecret = true
+		}
+		markSecret(fields[index].fields)
+	}
+}
@@ -0,0 +1,106 @@
+package reflector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+func TestSecret(t *testing.T) {
+	secret := Secret("password")
+	if secret.Value() != "password" {
+		t.Errorf("invalid value of secret: %s", secret.Value())
+	}
+	if s := fmt.Sprintf("%v %s %+v %#v", secret, secret, secret, secret); strings.Contains(s, "password") {
+		t.Errorf("secret is revealed by formatting: %s", s)
+	}
+	if data, err := json.Marshal(map[string]Secret{"password": secret}); err != nil {
+		t.Error(err)
+	} else if string(data) != `{"password":"******"}` {
+		t.Errorf("secret is revealed by json: %s", data)
+	}
+}
+
+func TestSecretFields(t *testing.T) {
+	type Config struct {
+		Password Secret `config:"password is_required"`
+		Token    string `config:"token is_secret has_default 'default-token'"`
+		Port     uint8  `config:"port is_secret"`
+		Database struct {
+			User string `config:"user"`
+		} `config:"database is_secret"`
+		Name string `config:"name"`
+	}
+	config := &Config{}
+	r, err := New(config, "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// template
+	if data, err := r.Template(providers.NewJsonDataProvider(nil)); err != nil {
+		t.Error(err)
+	} else if s := string(data.([]byte)); s != `{"database":{"user":"string secret"},"name":"string",`+
+		`"password":"string required secret","port":"uint secret",`+
+		`"token":"string secret default ******"}` {
+		t.Errorf("invalid template: %s", s)
+	}
+
+	// values
+	provider := providers.NewJsonDataProvider([]byte(`{"password":"p4ss","database":{"user":"admin"},"name":"app"}`))
+	if _, err := r.SetValues(provider); err != nil {
+		t.Fatal(err)
+	}
+	if config.Password.Value() != "p4ss" || config.Token != "default-token" || config.Database.User != "admin" {
+		t.Errorf("invalid values: %+v", config)
+	}
+
+	// export
+	if data, err := r.Export(providers.NewJsonDataProvider(nil)); err != nil {
+		t.Error(err)
+	} else if s := string(data.([]byte)); s != `{"database":{"user":"******"},"name":"app",`+
+		`"password":"******","port":"******","token":"******"}` {
+		t.Errorf("invalid export: %s", s)
+	}
+
+	// redacted export can not be loaded
+	redacted, err := r.Export(providers.NewJsonDataProvider(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = r.SetValues(providers.NewJsonDataProvider(redacted.([]byte)))
+	if errs, ok := err.(ValidationErrors); !ok || len(errs) != 4 || errs[0].Code != RedactedCode ||
+		errs[0].Path != "password" {
+		t.Errorf("redacted values must be reported: %v", err)
+	}
+
+	// dump keeps secret values for round trip
+	dumped, err := r.Dump(providers.NewJsonDataProvider(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(dumped.([]byte)); s != `{"database":{"user":"admin"},"name":"app",`+
+		`"password":"p4ss","port":0,"token":"default-token"}` {
+		t.Errorf("invalid dump: %s", s)
+	}
+	copied := &Config{}
+	if r, err := New(copied, "config"); err != nil {
+		t.Fatal(err)
+	} else if _, err := r.SetValues(providers.NewJsonDataProvider(dumped.([]byte))); err != nil {
+		t.Error(err)
+	} else if *copied != *config {
+		t.Errorf("invalid values after round trip: %+v", copied)
+	}
+
+	// errors
+	_, err = r.SetValues(providers.NewJsonDataProvider([]byte(`{"password":"p4ss","port":1234.5}`)))
+	if err == nil {
+		t.Fatal("error is expected")
+	}
+	if s := err.Error(); strings.Contains(s, "1234") {
+		t.Errorf("secret is revealed by error: %s", s)
+	}
+}
@@ -1,152 +1,321 @@
 package reflector
 
 import (
-	"reflect"
 	"fmt"
-	"errors"
+	"reflect"
+	"time"
+
+	"stash.abc.ee/micro/reflector/parser"
 )
 
+// Loading of values into reflection source
+type valueLoader struct {
+	errors    ValidationErrors
+	keyRing   *KeyRing            // keys for encrypted values
+	resolvers map[string]Resolver // resolvers of reflector
+	failed    map[string]bool     // paths of values which already have errors
+}
+
+// Add validation error for value, expected kind of pointer fields is kind of their element
+func (loader *valueLoader) addError(path string, field reflectionField, data interface{}, code ErrorCode) {
+	err := &ValidationError{
+		Path:     path,
+		Expected: field.fieldType.Kind(),
+		Code:     code,
+		value:    data,
+		secret:   field.isSecret,
+	}
+	if data != nil {
+		err.Received = fmt.Sprintf("%T", data)
+	}
+	loader.errors = append(loader.errors, err)
+}
+
+// Add validation error for value which is not bound to field
+func (loader *valueLoader) addFailure(err *ValidationError) {
+	if loader.failed == nil {
+		loader.failed = map[string]bool{}
+	}
+	loader.failed[err.Path] = true
+	loader.errors = append(loader.errors, err)
+}
+
+// Add validation error for reference which can not be resolved
+func (loader *valueLoader) addReferenceError(path string, field reflectionField, reference string, err error) {
+	loader.errors = append(loader.errors, &ValidationError{
+		Path:      path,
+		Expected:  field.fieldType.Kind(),
+		Received:  "string",
+		Code:      ReferenceCode,
+		Reference: reference,
+		Err:       err,
+		secret:    field.isSecret,
+	})
+}
+
 // Set fields values
-func setFieldsValues(value *reflect.Value, fields []reflectionField, data map[string]interface{}) error {
+func (loader *valueLoader) setFieldsValues(value *reflect.Value, fields []reflectionField,
+	data map[string]interface{}, path string) {
 
-	var buffer []reflectionField
+	// fields which are required depending on values of other fields
+	var dependent []reflectionField
 
 	for _, field := range fields {
+		valueField := structField(value, field)
+		fieldPath := fieldPath(path, field.configField.Name)
+
 		fieldValue, ok := data[field.configField.Name]
-		if !ok {
-			if field.configField.DefaultValue != nil {
-				// if field has default value use it
-				fieldValue = field.configField.DefaultValue
-			} else {
-				if field.configField.IsRequired {
-					// check if field depends on
-					if field.configField.DependsOn.ConfigFieldName != "" {
-						buffer = append(buffer, field)
-					} else {
-						return errors.New(fmt.Sprintf("value for field `%s` is required", field.configField.Name))
-					}
-				}
+		if !ok && field.configField.DefaultValue != nil {
+			// if field has default value use it
+			fieldValue = field.configField.DefaultValue
+		} else if !ok || (fieldValue == nil && field.isPointer) {
+			// explicit null of pointer field is unset value the same as missing value
+			if field.configField.DependsOn.ConfigFieldName != "" {
+				// check when all fields are loaded
+				dependent = append(dependent, field)
+			} else if field.configField.IsRequired {
+				loader.addError(fieldPath, field, nil, RequiredCode)
+				continue
 			}
 		}
 
-		var valueField reflect.Value
-		if value.Kind() == reflect.Ptr {
-			valueField = value.Elem().Field(field.fieldIndex)
-		} else {
-			valueField = value.Field(field.fieldIndex)
-		}
+		loader.setFieldValue(&valueField, field, fieldValue, fieldPath)
+	}
 
-		if err := setFieldValue(&valueField, field, fieldValue); err != nil {
-			return err
+	// check conditional requirements against loaded values
+	for _, field := range dependent {
+		sibling := fields[field.dependsOn]
+		if isConditionMet(structField(value, sibling), field.configField.DependsOn.Value) {
+			loader.addError(fieldPath(path, field.configField.Name), field, nil, RequiredCode)
 		}
 	}
-	return nil
 }
 
-func setFieldValue(value *reflect.Value, field reflectionField, data interface{}) error {
-	switch  value.Kind() {
-	case reflect.Struct:
-		if data, ok := data.(map[string]interface{}); !ok {
-			return errors.New(fmt.Sprintf("invalid data format for field `%s`", field.configField.Name))
-		} else {
-			return setFieldsValues(value, field.fields, data)
+// Get value of struct field
+func structField(value *reflect.Value, field reflectionField) reflect.Value {
+	if value.Kind() == reflect.Ptr {
+		return value.Elem().Field(field.fieldIndex)
+	}
+	return value.Field(field.fieldIndex)
+}
+
+// Check if loaded value meets condition of is_required_if,
+// without has_value any non zero value meets condition
+func isConditionMet(value reflect.Value, expected parser.TokenValue) bool {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return false
 		}
-	// Simple types
-	// Strings
+		value = value.Elem()
+	}
+	if expected == nil {
+		return !value.IsZero()
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		v, ok := expected.(bool)
+		return ok && v == value.Bool()
 	case reflect.String:
-		if data == nil {
-			data = ""
-		}
-		if v, ok := data.(string); !ok {
-			return errors.New(fmt.Sprintf("invalid type `%T` for field `%s` expected `%s`", data, field.configField.Name, value.Kind()))
-		} else {
-			value.SetString(v)
-		}
-	// Processing integers
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int64:
-		if data == nil {
-			data = 0
-		}
-		var castingError bool = true
-		switch reflect.TypeOf(data).Kind() {
-		case reflect.Int:
-			if v, ok := data.(int); ok {
-				value.SetInt(int64(v))
-				castingError = false
-			}
-		case reflect.Int8:
-			if v, ok := data.(int8); ok {
-				value.SetInt(int64(v))
-				castingError = false
-			}
-		case reflect.Int16:
-			if v, ok := data.(int16); ok {
-				value.SetInt(int64(v))
-				castingError = false
-			}
-		case reflect.Int32:
-			if v, ok := data.(int32); ok {
-				value.SetInt(int64(v))
-				castingError = false
-			}
-		case reflect.Int64:
-			if v, ok := data.(int64); ok {
-				value.SetInt(int64(v))
-				castingError = false
-			}
-		}
-		if castingError {
-			return errors.New(fmt.Sprintf("invalid type `%T` for field `%s` expected %s", data,
-				field.configField.Name, value.Kind()))
-		}
+		v, ok := expected.(string)
+		return ok && v == value.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := toInt64(expected)
+		return err == nil && v == value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := toUint64(expected)
+		return err == nil && v == value.Uint()
+	case reflect.Float32:
+		v, err := toFloat64(expected)
+		return err == nil && float32(v) == float32(value.Float())
+	case reflect.Float64:
+		v, err := toFloat64(expected)
+		return err == nil && v == value.Float()
+	}
+	return false
+}
 
-	case reflect.Float32, reflect.Float64:
-		if data == nil {
-			data = 0.0
-		}
-		if v, ok := data.(float64); !ok {
-			return errors.New(fmt.Sprintf("invalid type `%T` for field `%s`", data, field.configField.Name))
-		} else {
-			value.SetFloat(v)
+// Setter of value for kind of field, chosen when schema is compiled
+type valueSetter func(loader *valueLoader, value *reflect.Value, field reflectionField, data interface{},
+	path string)
+
+// Get setter for type of field without pointer
+func setterOf(fieldType reflect.Type) valueSetter {
+	switch fieldType.Kind() {
+	case reflect.Struct:
+		if fieldType == timeType {
+			return (*valueLoader).setTimeValue
 		}
+		return (*valueLoader).setStructValue
+	case reflect.String:
+		return (*valueLoader).setStringValue
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return (*valueLoader).setNumberValue
 	case reflect.Bool:
-		if data == nil {
-			data = false
-		}
-		if v, ok := data.(bool); !ok {
-			return errors.New(fmt.Sprintf("invalid type `%T` for field `%s`", data, field.configField.Name))
-		} else {
-			value.SetBool(v)
-		}
-	//
-	// Slices
-	//
+		return (*valueLoader).setBoolValue
+	case reflect.Map:
+		return (*valueLoader).setMapValue
 	case reflect.Slice:
-		fieldType := field.fieldType
-		if sliceData, ok := data.([]interface{}); !ok {
-			return errors.New(fmt.Sprintf("invalid type `%T` for field `%s`", data, field.configField.Name))
-		} else {
-			// create slice
-			slice := reflect.MakeSlice(reflect.SliceOf(fieldType.Elem()), 0, 0)
-			for index := 0; index < len(sliceData); index++ {
-				// create new value for slice elements
-				// New creates pointer to type - need to use Elem() to get value
-				elemValue := reflect.New(fieldType.Elem())
-				elemValue = elemValue.Elem()
-				if err := setFieldValue(&elemValue, field, sliceData[index]); err != nil {
-					return errors.New(fmt.Sprintf("error (%s) create slice element with index: %d",
-						err, index))
-				}
-				slice = reflect.Append(slice, elemValue) // add element to slice
-			}
-			if slice.Len() == 0 && field.configField.IsRequired {
-				field.hasValue = false
-				//return errors.New(fmt.Sprintf("value for field `%s` is required", field.name))
+		return (*valueLoader).setSliceValue
+	}
+	return nil
+}
 
-			}
-			value.Set(slice)
-		}
+// Set value of field, pointers are allocated only when there is a value
+func (loader *valueLoader) setFieldValue(value *reflect.Value, field reflectionField, data interface{},
+	path string) {
+	if loader.failed[path] || field.setter == nil {
+		return
 	}
+	if !field.isPointer {
+		loader.setValue(value, field, data, path)
+		return
+	}
+	if data == nil {
+		value.Set(reflect.Zero(value.Type()))
+		return
+	}
+	elemValue := reflect.New(field.fieldType)
+	elem := elemValue.Elem()
+	loader.setValue(&elem, field, data, path)
+	value.Set(elemValue)
+}
 
-	return nil
-}
\ No newline at end of file
+// Set value of field without pointer, references are resolved before setter of kind
+func (loader *valueLoader) setValue(value *reflect.Value, field reflectionField, data interface{}, path string) {
+	data, ok := loader.resolveValue(field, data, path)
+	if ok {
+		field.setter(loader, value, field, data, path)
+	}
+}
+
+func (loader *valueLoader) setStructValue(value *reflect.Value, field reflectionField, data interface{},
+	path string) {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	if structData, ok := data.(map[string]interface{}); !ok {
+		loader.addError(path, field, data, InvalidTypeCode)
+	} else {
+		loader.setFieldsValues(value, field.fields, structData, path)
+	}
+}
+
+func (loader *valueLoader) setStringValue(value *reflect.Value, field reflectionField, data interface{},
+	path string) {
+	if data == nil {
+		data = ""
+	}
+	if v, ok := data.(string); !ok {
+		loader.addError(path, field, data, InvalidTypeCode)
+	} else if field.isSecret && v == secretMask {
+		// redacted output of Export is not a valid secret
+		loader.addError(path, field, data, RedactedCode)
+	} else {
+		value.SetString(v)
+	}
+}
+
+func (loader *valueLoader) setNumberValue(value *reflect.Value, field reflectionField, data interface{},
+	path string) {
+	if data == nil {
+		data = 0
+	}
+	switch setNumericValue(value, data) {
+	case errNumericOverflow:
+		loader.addError(path, field, data, OverflowCode)
+	case errNumericTruncation:
+		loader.addError(path, field, data, TruncationCode)
+	case errNumericType:
+		loader.addError(path, field, data, InvalidTypeCode)
+	}
+}
+
+func (loader *valueLoader) setBoolValue(value *reflect.Value, field reflectionField, data interface{},
+	path string) {
+	if data == nil {
+		data = false
+	}
+	if v, ok := data.(bool); !ok {
+		loader.addError(path, field, data, InvalidTypeCode)
+	} else {
+		value.SetBool(v)
+	}
+}
+
+// Set map with string keys, values are set by precompiled field of elements
+func (loader *valueLoader) setMapValue(value *reflect.Value, field reflectionField, data interface{},
+	path string) {
+	if data == nil {
+		return
+	}
+	mapData, ok := data.(map[string]interface{})
+	if !ok {
+		loader.addError(path, field, data, InvalidTypeCode)
+		return
+	}
+	mapType := value.Type()
+	mapValue := reflect.MakeMapWithSize(mapType, len(mapData))
+	for key, elemData := range mapData {
+		elemValue := reflect.New(mapType.Elem()).Elem()
+		loader.setFieldValue(&elemValue, *field.elem, elemData, fieldPath(path, key))
+		mapValue.SetMapIndex(reflect.ValueOf(key).Convert(mapType.Key()), elemValue)
+	}
+	value.Set(mapValue)
+}
+
+// Set slice, elements are set by precompiled field of elements
+func (loader *valueLoader) setSliceValue(value *reflect.Value, field reflectionField, data interface{},
+	path string) {
+	if data == nil {
+		return
+	}
+	sliceData, ok := toSlice(data)
+	if !ok {
+		loader.addError(path, field, data, InvalidTypeCode)
+		return
+	}
+	slice := reflect.MakeSlice(value.Type(), len(sliceData), len(sliceData))
+	for index := range sliceData {
+		elemValue := slice.Index(index)
+		loader.setFieldValue(&elemValue, *field.elem, sliceData[index], indexPath(path, index))
+	}
+	value.Set(slice)
+}
+
+// Convert slice of any type (e.g. default value) to slice of interfaces
+func toSlice(data interface{}) ([]interface{}, bool) {
+	if sliceData, ok := data.([]interface{}); ok {
+		return sliceData, true
+	}
+	sliceValue := reflect.ValueOf(data)
+	if sliceValue.Kind() != reflect.Slice {
+		return nil, false
+	}
+	sliceData := make([]interface{}, sliceValue.Len())
+	for index := range sliceData {
+		sliceData[index] = sliceValue.Index(index).Interface()
+	}
+	return sliceData, true
+}
+
+// Set datetime value from time.Time or string in RFC 3339 format
+func (loader *valueLoader) setTimeValue(value *reflect.Value, field reflectionField, data interface{},
+	path string) {
+	switch v := data.(type) {
+	case nil:
+		value.Set(reflect.Zero(timeType))
+	case time.Time:
+		value.Set(reflect.ValueOf(v))
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err != nil {
+			loader.addError(path, field, data, InvalidFormatCode)
+		} else {
+			value.Set(reflect.ValueOf(t))
+		}
+	default:
+		loader.addError(path, field, data, InvalidTypeCode)
+	}
+}
@@ -0,0 +1,73 @@
+package reflector
+
+import (
+	"reflect"
+	"testing"
+
+	"stash.abc.ee/micro/reflector/providers"
+)
+
+func TestConditionalRequirement(t *testing.T) {
+	type Config struct {
+		SslMode bool   `config:"ssl_mode"`
+		SslCert string `config:"ssl_cert is_required_if ssl_mode has_value true"`
+		Mode    string `config:"mode"`
+		Token   string `config:"token is_required_if mode has_value 'auth'"`
+		Port    int    `config:"port"`
+		Limit   int    `config:"limit is_required_if port has_value 80"`
+		Proxy   string `config:"proxy"`
+		Auth    string `config:"auth is_required_if proxy"`
+	}
+	tests := []struct {
+		data  string
+		paths []string
+	}{
+		{data: `{}`},
+		{data: `{"ssl_mode":false}`},
+		{data: `{"ssl_mode":true}`, paths: []string{"ssl_cert"}},
+		{data: `{"ssl_mode":true,"ssl_cert":"cert.pem"}`},
+		{data: `{"mode":"auth","port":80}`, paths: []string{"token", "limit"}},
+		{data: `{"mode":"none","port":8080}`},
+		{data: `{"proxy":"localhost"}`, paths: []string{"auth"}},
+	}
+	for _, test := range tests {
+		r, err := New(&Config{}, "config")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = r.SetValues(providers.NewJsonDataProvider([]byte(test.data)))
+		errs, _ := err.(ValidationErrors)
+		if len(errs) != len(test.paths) {
+			t.Errorf("%s: invalid errors: %v", test.data, err)
+			continue
+		}
+		for i, path := range test.paths {
+			if errs[i].Path != path || errs[i].Code != RequiredCode {
+				t.Errorf("%s: invalid error: %s", test.data, errs[i])
+			}
+		}
+	}
+}
+
+func TestIsConditionMet(t *testing.T) {
+	var port uint16 = 443
+	var ratio float32 = 1.5
+	tests := []struct {
+		value    interface{}
+		expected interface{}
+		result   bool
+	}{
+		{value: &port, expected: int64(443), result: true},
+		{value: &port, expected: 443.0, result: true},
+		{value: &port, expected: "443", result: false},
+		{value: &ratio, expected: 1.5, result: true},
+		{value: (*int)(nil), expected: nil, result: false},
+		{value: "value", expected: nil, result: true},
+		{value: true, expected: false, result: false},
+	}
+	for _, test := range tests {
+		if result := isConditionMet(reflect.ValueOf(test.value), test.expected); result != test.result {
+			t.Errorf("%v == %v must be %v", test.value, test.expected, test.result)
+		}
+	}
+}